@@ -0,0 +1,267 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	configKindString = "string"
+	configKindInt    = "int"
+	configKindBool   = "bool"
+
+	// configEnvPrefix prefixes the environment variables of the config keys, e.g. OPENGEMINI_HOST
+	configEnvPrefix = "OPENGEMINI_"
+	// ConfigProfileEnv selects the profile when --profile is not given
+	ConfigProfileEnv = configEnvPrefix + "PROFILE"
+)
+
+// ConfigKey is a connection setting which can be stored in the config file, it is named after the flag it sets
+type ConfigKey struct {
+	Name        string
+	Kind        string
+	Description string
+}
+
+// EnvName returns the environment variable overriding the key, e.g. OPENGEMINI_CERT_KEY for cert-key
+func (k ConfigKey) EnvName() string {
+	return configEnvPrefix + strings.ToUpper(strings.ReplaceAll(k.Name, "-", "_"))
+}
+
+var ConfigKeys = []ConfigKey{
+	{Name: "host", Kind: configKindString, Description: "ts-sql host to connect to"},
+	{Name: "port", Kind: configKindInt, Description: "ts-sql tcp port to connect to"},
+	{Name: "socket", Kind: configKindString, Description: "openGemini unix domain socket to connect to"},
+	{Name: "username", Kind: configKindString, Description: "username to connect to openGemini"},
+	{Name: "password", Kind: configKindString, Description: "password to connect to openGemini"},
+	{Name: "database", Kind: configKindString, Description: "database to connect to openGemini"},
+	{Name: "precision", Kind: configKindString, Description: "format of the timestamp: rfc3339, h, m, s, ms, u or ns"},
+	{Name: "timeout", Kind: configKindInt, Description: "request-timeout in mill-seconds"},
+	{Name: "ssl", Kind: configKindBool, Description: "use https for connecting to openGemini"},
+	{Name: "insecure-tls", Kind: configKindBool, Description: "ignore ssl verification when connecting openGemini by https"},
+	{Name: "cacert", Kind: configKindString, Description: "CA certificate to verify peer against when connecting openGemini by https"},
+	{Name: "cert", Kind: configKindString, Description: "client certificate file when connecting openGemini by https"},
+	{Name: "cert-key", Kind: configKindString, Description: "client certificate password"},
+	{Name: "production", Kind: configKindBool, Description: "mark the connection as production, the prompt of the interactive shell is shown in red"},
+	{Name: "prompt-template", Kind: configKindString, Description: "prompt of the interactive shell with the placeholders {user}, {host}, {port}, {db}, {rp} and {profile}"},
+	{Name: "theme", Kind: configKindString, Description: "colors of the syntax highlighting in the interactive shell: dark, light or off"},
+	{Name: "insecure-hostname", Kind: configKindBool, Description: "ignore server certificate hostname verification when connecting openGemini by https"},
+}
+
+func lookupConfigKey(name string) (ConfigKey, error) {
+	for _, key := range ConfigKeys {
+		if key.Name == name {
+			return key, nil
+		}
+	}
+	var names = make([]string, 0, len(ConfigKeys))
+	for _, key := range ConfigKeys {
+		names = append(names, key.Name)
+	}
+	return ConfigKey{}, fmt.Errorf("unknown config key %q, supported keys: %s", name, strings.Join(names, ", "))
+}
+
+// ConfigFile is the content of ~/.ts-cli/config.toml. The default section applies to every run and
+// the named profiles selected by --profile override it:
+//
+//	[default]
+//	host = "localhost"
+//
+//	[profiles.prod]
+//	host = "prod.example.com"
+//	ssl = true
+type ConfigFile struct {
+	Default  map[string]any            `toml:"default"`
+	Profiles map[string]map[string]any `toml:"profiles"`
+}
+
+// DefaultConfigPath returns ~/.ts-cli/config.toml
+func DefaultConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".ts-cli", "config.toml"), nil
+}
+
+// LoadConfigFile reads the config file, a missing file is an empty config
+func LoadConfigFile(path string) (*ConfigFile, error) {
+	var file = new(ConfigFile)
+	_, err := toml.DecodeFile(path, file)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read config file %s: %w", path, err)
+	}
+	for name := range file.Default {
+		if _, err = lookupConfigKey(name); err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+	for profile, values := range file.Profiles {
+		for name := range values {
+			if _, err = lookupConfigKey(name); err != nil {
+				return nil, fmt.Errorf("config file %s, profile %s: %w", path, profile, err)
+			}
+		}
+	}
+	return file, nil
+}
+
+// Save writes the config file, which is only readable by the current user as it may contain passwords
+func (f *ConfigFile) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err = toml.NewEncoder(file).Encode(f); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ProfileNames returns the sorted names of the profiles
+func (f *ConfigFile) ProfileNames() []string {
+	var names = make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Values merges the default section, the profile and the environment variables, the later ones win.
+// An empty profile only uses the default section.
+func (f *ConfigFile) Values(profile string, getenv func(string) string) (map[string]string, error) {
+	var values = make(map[string]string)
+	for name, value := range f.Default {
+		values[name] = fmt.Sprint(value)
+	}
+	if profile != "" {
+		section, ok := f.Profiles[profile]
+		if !ok {
+			return nil, fmt.Errorf("profile %q not found in the config file", profile)
+		}
+		for name, value := range section {
+			values[name] = fmt.Sprint(value)
+		}
+	}
+	for _, key := range ConfigKeys {
+		if value := getenv(key.EnvName()); value != "" {
+			values[key.Name] = value
+		}
+	}
+	return values, nil
+}
+
+// Set stores the value of the key in the profile, an empty profile is the default section
+func (f *ConfigFile) Set(profile, name, value string) error {
+	key, err := lookupConfigKey(name)
+	if err != nil {
+		return err
+	}
+	var typed any = value
+	switch key.Kind {
+	case configKindInt:
+		if typed, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("invalid %s %q, it must be an integer", name, value)
+		}
+	case configKindBool:
+		if typed, err = strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("invalid %s %q, it must be true or false", name, value)
+		}
+	}
+	if profile == "" {
+		if f.Default == nil {
+			f.Default = make(map[string]any)
+		}
+		f.Default[name] = typed
+		return nil
+	}
+	if f.Profiles == nil {
+		f.Profiles = make(map[string]map[string]any)
+	}
+	if f.Profiles[profile] == nil {
+		f.Profiles[profile] = make(map[string]any)
+	}
+	f.Profiles[profile][name] = typed
+	return nil
+}
+
+// ConfigCommand manages the config file by `ts-cli config list|show|set`
+type ConfigCommand struct {
+	Path    string
+	Profile string
+}
+
+// List writes the names of the profiles
+func (c *ConfigCommand) List(w io.Writer) error {
+	file, err := LoadConfigFile(c.Path)
+	if err != nil {
+		return err
+	}
+	for _, name := range file.ProfileNames() {
+		_, _ = fmt.Fprintln(w, name)
+	}
+	return nil
+}
+
+// Show writes the settings of the profile merged with the default section and the environment
+// variables, the password is masked
+func (c *ConfigCommand) Show(w io.Writer) error {
+	file, err := LoadConfigFile(c.Path)
+	if err != nil {
+		return err
+	}
+	values, err := file.Values(c.Profile, os.Getenv)
+	if err != nil {
+		return err
+	}
+	for _, key := range ConfigKeys {
+		value, ok := values[key.Name]
+		if !ok {
+			continue
+		}
+		if key.Name == "password" && value != "" {
+			value = "******"
+		}
+		_, _ = fmt.Fprintf(w, "%s = %s\n", key.Name, value)
+	}
+	return nil
+}
+
+// Set stores the value of the key in the profile and saves the config file
+func (c *ConfigCommand) Set(name, value string) error {
+	file, err := LoadConfigFile(c.Path)
+	if err != nil {
+		return err
+	}
+	if err = file.Set(c.Profile, name, value); err != nil {
+		return err
+	}
+	return file.Save(c.Path)
+}
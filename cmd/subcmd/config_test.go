@@ -0,0 +1,92 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFile_Values(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[default]
+host = "localhost"
+port = 8086
+database = "db0"
+
+[profiles.prod]
+host = "prod.example.com"
+ssl = true
+`), 0600))
+	file, err := LoadConfigFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"prod"}, file.ProfileNames())
+
+	env := map[string]string{"OPENGEMINI_PASSWORD": "secret", "OPENGEMINI_DATABASE": ""}
+	getenv := func(key string) string { return env[key] }
+
+	values, err := file.Values("", getenv)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"host": "localhost", "port": "8086", "database": "db0", "password": "secret"}, values)
+
+	values, err = file.Values("prod", getenv)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"host": "prod.example.com", "port": "8086", "database": "db0", "ssl": "true", "password": "secret"}, values)
+
+	_, err = file.Values("test", getenv)
+	require.ErrorContains(t, err, `profile "test" not found`)
+}
+
+func TestConfigFile_UnknownKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte("[profiles.prod]\nhots = \"prod.example.com\"\n"), 0600))
+	_, err := LoadConfigFile(path)
+	require.ErrorContains(t, err, `unknown config key "hots"`)
+}
+
+func TestConfigCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".ts-cli", "config.toml")
+	command := &ConfigCommand{Path: path}
+	require.NoError(t, command.Set("password", "secret"))
+	require.ErrorContains(t, command.Set("port", "high"), `invalid port "high"`)
+	require.ErrorContains(t, command.Set("color", "on"), `unknown config key "color"`)
+
+	command.Profile = "prod"
+	require.NoError(t, command.Set("host", "prod.example.com"))
+	require.NoError(t, command.Set("port", "9086"))
+	require.NoError(t, command.Set("insecure-tls", "true"))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	var buf bytes.Buffer
+	require.NoError(t, command.List(&buf))
+	require.Equal(t, "prod\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, command.Show(&buf))
+	require.Equal(t, "host = prod.example.com\nport = 9086\npassword = ******\ninsecure-tls = true\n", buf.String())
+}
+
+func TestConfigKey_EnvName(t *testing.T) {
+	require.Equal(t, "OPENGEMINI_HOST", ConfigKey{Name: "host"}.EnvName())
+	require.Equal(t, "OPENGEMINI_CERT_KEY", ConfigKey{Name: "cert-key"}.EnvName())
+}
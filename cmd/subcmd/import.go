@@ -24,8 +24,10 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/openGemini/opengemini-client-go/opengemini"
@@ -58,8 +60,17 @@ const (
 	timeFilterToken            = "# openGemini EXPORT:"
 )
 
+// importStdin is the --path of the standard input
+const importStdin = "-"
+
+// importReadAhead is the number of chunks of importChunkLines lines or rows read ahead of the parser
+const (
+	importReadAhead  = 16
+	importChunkLines = 1024
+)
+
 var (
-	builderEntities = make(map[string]opengemini.WriteRequestBuilder)
+	builderEntities = newWriteRequestBuilders()
 )
 
 func NewColumnWriterClient(cfg *ImportConfig) (proto.WriteServiceClient, error) {
@@ -103,7 +114,8 @@ func NewColumnWriterClient(cfg *ImportConfig) (proto.WriteServiceClient, error)
 
 type ImportConfig struct {
 	*core.CommandLineConfig
-	Path            string
+	// Paths are the files, glob patterns and directories to import, - is stdin
+	Paths           []string
 	Format          string
 	ColumnWrite     bool
 	ColumnWritePort int
@@ -111,6 +123,20 @@ type ImportConfig struct {
 	Tags            []string
 	Fields          []string
 	TimeField       string
+
+	// Workers is the number of goroutines writing the batches concurrently
+	Workers int
+	// MaxInflightBatches is the number of batches parsed but not written yet, 0 is twice the workers
+	MaxInflightBatches int
+	// Retries is the number of times a batch failed by a transient error is written again
+	Retries int
+	// RetryBackoff is the delay before the first retry, it doubles up to RetryMaxBackoff
+	RetryBackoff    time.Duration
+	RetryMaxBackoff time.Duration
+	// RejectFile collects the batches failed after the retries, in the format of the imported file
+	RejectFile string
+	// Resume continues the line protocol file from its checkpoint saved by the interrupted import
+	Resume bool
 }
 
 type ImportCommand struct {
@@ -118,15 +144,35 @@ type ImportCommand struct {
 	httpClient  core.HttpClient
 	writeClient proto.WriteServiceClient
 	fsm         *ImportFileFSM
+	pipeline    *importPipeline
+	// rejects collects the batches failed after the retries, nil without --reject-file
+	rejects *rejectWriter
+	// checkpoints saves the progress of the line protocol file, nil for the other formats
+	checkpoints *importCheckpointer
+	progress    *importProgress
 }
 
 func (c *ImportCommand) Run(config *ImportConfig) error {
 	if config.Format == "" {
 		config.Format = importFormatLineProtocol
 	}
+	if config.Resume && config.Format != importFormatLineProtocol {
+		return fmt.Errorf("--resume only supports --format %s", importFormatLineProtocol)
+	}
+	// the json formats are written as line protocol, which could not be imported with the same --format
+	if config.RejectFile != "" && config.Format != importFormatLineProtocol && config.Format != importFormatCSV {
+		return fmt.Errorf("--reject-file only supports --format %s and %s", importFormatLineProtocol, importFormatCSV)
+	}
 	if config.BatchSize <= 0 {
 		config.BatchSize = common.DefaultBatchSize
 	}
+	if config.Workers <= 0 {
+		config.Workers = common.DefaultImportWorkers
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaultRetryBackoff
+	}
+	config.RetryMaxBackoff = max(config.RetryMaxBackoff, config.RetryBackoff)
 
 	httpClient, err := core.NewHttpClient(config.CommandLineConfig)
 	if err != nil {
@@ -149,91 +195,124 @@ func (c *ImportCommand) Run(config *ImportConfig) error {
 	}
 
 	c.cfg = config
-	c.fsm = new(ImportFileFSM)
-	return c.process()
+	files, err := importFiles(config.Paths, config.RejectFile)
+	if err != nil {
+		slog.Error("find files to import failed", "reason", err)
+		return err
+	}
+	// an interrupted import stops reading, the batches not written are kept by the checkpoint
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var results = make([]importFileResult, 0, len(files))
+	var errs []error
+	for _, path := range files {
+		c.fsm = new(ImportFileFSM)
+		c.pipeline, c.progress, c.checkpoints = nil, nil, nil
+		err = c.process(ctx, path)
+		results = append(results, c.fileResult(path, err))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("import %s failed: %w", path, err))
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	if len(files) > 1 {
+		writeFileResults(os.Stdout, results)
+	}
+	return errors.Join(errs...)
 }
 
-func (c *ImportCommand) process() error {
-	file, err := os.Open(c.cfg.Path)
+// process imports the file at path, - is stdin
+func (c *ImportCommand) process(ctx context.Context, path string) error {
+	var file = os.Stdin
+	if path != importStdin {
+		var err error
+		file, err = os.Open(path)
+		if err != nil {
+			slog.Error("open file failed", "file", path, "reason", err)
+			return err
+		}
+		defer file.Close()
+	}
+	info, err := file.Stat()
 	if err != nil {
-		slog.Error("open file failed", "file", c.cfg.Path, "reason", err)
 		return err
 	}
-	defer file.Close()
-	var ctx = context.Background()
+	switch {
+	case c.cfg.Format != importFormatLineProtocol:
+	case path == importStdin:
+		if c.cfg.Resume {
+			slog.Warn("stdin cannot be resumed, import it from the beginning")
+		}
+	default:
+		if err = c.openCheckpoint(path, file); err != nil {
+			slog.Error("open checkpoint failed", "file", path, "reason", err)
+			return err
+		}
+	}
+	// the size of stdin is unknown
+	var size int64
+	if info.Mode().IsRegular() {
+		size = info.Size()
+	}
+	c.startPipeline(ctx)
+	c.startProgress(size, c.fsm.nextOffset)
+	err = c.parse(ctx, c.progress.reader(file))
+	err = errors.Join(err, c.stopPipeline())
+	if err == nil {
+		// the writers are interrupted after the file is parsed
+		err = ctx.Err()
+	}
+	c.finishCheckpoint(path)
+	if err != nil {
+		return err
+	}
+	slog.Info("process finished", "path", path)
+	return nil
+}
+
+// parse reads the file and sends the batches to the writers of the pipeline
+func (c *ImportCommand) parse(ctx context.Context, file io.Reader) error {
 	switch c.cfg.Format {
 	case importFormatLineProtocol:
-		scanner := bufio.NewReader(file)
-		for {
-			line, err := scanner.ReadBytes('\n')
-			if err != nil {
-				if err == io.EOF {
-					if len(line) > 0 {
-						fsmCall, err := c.fsm.processLineProtocol(string(line))
-						if err != nil {
-							slog.Error("process line protocol failed", "reason", err)
-							break
-						}
-						err = fsmCall(ctx, c)
-						if err != nil {
-							slog.Error("call line protocol fsm function failed", "reason", err)
-						}
-					}
-					break
+		for chunk := range readLines(ctx, file, c.fsm.nextOffset) {
+			for _, line := range chunk {
+				c.fsm.lineOffset, c.fsm.nextOffset = line.offset, line.offset+int64(len(line.text))
+				fsmCall, err := c.fsm.processLineProtocol(line.text)
+				if err != nil {
+					slog.Error("process line protocol failed", "reason", err)
+					continue
+				}
+				err = fsmCall(ctx, c)
+				if err != nil {
+					slog.Error("call line protocol fsm function failed", "reason", err)
+					continue
 				}
-				slog.Error("read line failed", "reason", err)
-				continue
-			}
-			fsmCall, err := c.fsm.processLineProtocol(string(line))
-			if err != nil {
-				slog.Error("process line protocol failed", "reason", err)
-				continue
-			}
-			err = fsmCall(ctx, c)
-			if err != nil {
-				slog.Error("call line protocol fsm function failed", "reason", err)
-				continue
 			}
+			c.saveCheckpoint(false)
 		}
-		if err := c.fsm.clearBuffer()(ctx, c); err != nil {
-			slog.Error("clear buffer failed", "reason", err)
-		}
-		slog.Info("process finished", "path", c.cfg.Path)
-		return nil
 	case importFormatCSV:
 		slog.Info("tips: csv file import only support by column write protocol")
-		csvReader := csv.NewReader(file)
-		csvReader.Comment = '#'
-		for {
-			row, err := csvReader.Read()
-			if err != nil {
-				if err == io.EOF {
-					break
+		for chunk := range readRows(ctx, file) {
+			for _, row := range chunk {
+				fsmCall, err := c.fsm.processCSV(row)
+				if err != nil {
+					slog.Error("process csv line failed", "reason", err)
+					continue
+				}
+				err = fsmCall(ctx, c)
+				if err != nil {
+					slog.Error("call csv line fsm function failed", "reason", err)
+					continue
 				}
-				slog.Error("read csv line failed", "reason", err)
-				continue
-			}
-			fsmCall, err := c.fsm.processCSV(row)
-			if err != nil {
-				slog.Error("process csv line failed", "reason", err)
-				continue
-			}
-			err = fsmCall(ctx, c)
-			if err != nil {
-				slog.Error("call csv line fsm function failed", "reason", err)
-				continue
 			}
 		}
-		if err := c.fsm.clearBuffer()(ctx, c); err != nil {
-			slog.Error("clear buffer failed", "reason", err)
-		}
-		slog.Info("process finished", "path", c.cfg.Path)
-		return nil
 	// support jsonProm
 	case importFormatJSONProm:
 		slog.Info("tips: prom json file import only support by row write protocol")
 		dec := json.NewDecoder(file)
-		for dec.More() {
+		for ctx.Err() == nil && dec.More() {
 			fsmCall, err := c.fsm.processJsonP(dec)
 			if err != nil {
 				slog.Error("process prom json line failed", "reason", err)
@@ -245,16 +324,11 @@ func (c *ImportCommand) process() error {
 				continue
 			}
 		}
-		if err := c.fsm.clearBuffer()(ctx, c); err != nil {
-			slog.Error("clear buffer failed", "reason", err)
-		}
-		slog.Info("process finished", "path", c.cfg.Path)
-		return nil
 	// support jsonInflux
 	case importFormatJSONInflux:
 		slog.Info("tips: influx json file import only support by row write protocol")
 		dec := json.NewDecoder(file)
-		for dec.More() {
+		for ctx.Err() == nil && dec.More() {
 			fsmCall, err := c.fsm.processJsonI(dec)
 			if err != nil {
 				slog.Error("process influx json line failed", "reason", err)
@@ -266,14 +340,102 @@ func (c *ImportCommand) process() error {
 				continue
 			}
 		}
-		if err := c.fsm.clearBuffer()(ctx, c); err != nil {
-			slog.Error("clear buffer failed", "reason", err)
-		}
-		slog.Info("process finished", "path", c.cfg.Path)
-		return nil
 	default:
 		return fmt.Errorf("unknown --format %s, only support line_protocol, csv", c.cfg.Format)
 	}
+	// the batches being filled are left to the checkpoint
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := c.fsm.clearBuffer()(ctx, c); err != nil {
+		slog.Error("clear buffer failed", "reason", err)
+	}
+	return nil
+}
+
+// importLine is a line of the file and the byte offset it starts at
+type importLine struct {
+	text   string
+	offset int64
+}
+
+// readLines reads the lines ahead of the parser in chunks, the last line may miss the line break.
+// offset is the position of the file the reading starts at, the reading stops when ctx is done.
+func readLines(ctx context.Context, file io.Reader, offset int64) <-chan []importLine {
+	chunks := make(chan []importLine, importReadAhead)
+	go func() {
+		defer close(chunks)
+		reader := bufio.NewReader(file)
+		chunk := make([]importLine, 0, importChunkLines)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				chunk = append(chunk, importLine{text: string(line), offset: offset})
+				offset += int64(len(line))
+			}
+			if len(chunk) == importChunkLines || err != nil && len(chunk) > 0 {
+				if !sendChunk(ctx, chunks, chunk) {
+					return
+				}
+				chunk = make([]importLine, 0, importChunkLines)
+			}
+			if err != nil {
+				if err != io.EOF {
+					slog.Error("read line failed", "reason", err)
+				}
+				return
+			}
+		}
+	}()
+	return chunks
+}
+
+// readRows reads the csv rows ahead of the parser in chunks, the lines starting with # are skipped
+func readRows(ctx context.Context, file io.Reader) <-chan [][]string {
+	chunks := make(chan [][]string, importReadAhead)
+	go func() {
+		defer close(chunks)
+		csvReader := csv.NewReader(file)
+		csvReader.Comment = '#'
+		chunk := make([][]string, 0, importChunkLines)
+		for {
+			row, err := csvReader.Read()
+			if err == nil {
+				chunk = append(chunk, row)
+			}
+			if len(chunk) == importChunkLines || err == io.EOF && len(chunk) > 0 {
+				if !sendChunk(ctx, chunks, chunk) {
+					return
+				}
+				chunk = make([][]string, 0, importChunkLines)
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				slog.Error("read csv line failed", "reason", err)
+				// a malformed row is skipped, the other errors stop reading
+				var parseErr *csv.ParseError
+				if !errors.As(err, &parseErr) {
+					if len(chunk) > 0 {
+						sendChunk(ctx, chunks, chunk)
+					}
+					return
+				}
+			}
+		}
+	}()
+	return chunks
+}
+
+// sendChunk hands the chunk to the parser, it reports false when ctx is done and the parser stopped
+func sendChunk[T any](ctx context.Context, chunks chan<- T, chunk T) bool {
+	select {
+	case chunks <- chunk:
+		return true
+	case <-ctx.Done():
+		return false
+	}
 }
 
 type ImportState int
@@ -284,15 +446,26 @@ const (
 )
 
 type ImportFileFSM struct {
-	state            ImportState
-	database         string
-	retentionPolicy  string
-	measurement      string
-	tagMap           map[string]FieldPos
-	fieldMap         map[string]FieldPos
-	timeField        FieldPos
-	batchLPBuffer    []string
-	batchPointBuffer []*opengemini.Point
+	state           ImportState
+	database        string
+	retentionPolicy string
+	measurement     string
+	tagMap          map[string]FieldPos
+	fieldMap        map[string]FieldPos
+	timeField       FieldPos
+	// batchLPBuffer and batchPointBuffer are the batches being filled for each writer
+	batchLPBuffer    [][]string
+	batchPointBuffer [][]*opengemini.Point
+	batchRowBuffer   [][][]string
+	// batchOffsets are the offsets of the first lines of batchLPBuffer
+	batchOffsets []int64
+	// lineOffset and nextOffset are the offsets of the line protocol being processed and of the
+	// line after it
+	lineOffset int64
+	nextOffset int64
+	// resumeOffsets are the shard offsets of the resumed checkpoint, the lines of a writer before
+	// its offset were acknowledged by the interrupted import and are skipped
+	resumeOffsets []int64
 }
 
 type FieldPos struct {
@@ -307,17 +480,8 @@ var FSMCallEmpty = func(ctx context.Context, command *ImportCommand) error { ret
 // clear the last batch data
 func (fsm *ImportFileFSM) clearBuffer() FSMCall {
 	return func(ctx context.Context, command *ImportCommand) error {
-		var errs error
-		for len(fsm.batchLPBuffer) != 0 {
-			err := command.excuteByLPBuffer(ctx)
-			errs = errors.Join(errs, err)
-		}
-
-		if len(fsm.batchPointBuffer) != 0 { // only support column write
-			err := command.executeByPointBuffer(ctx)
-			errs = errors.Join(errs, err)
-		}
-		return errs
+		command.flush()
+		return nil
 	}
 }
 
@@ -328,8 +492,7 @@ func (fsm *ImportFileFSM) processLineProtocol(data string) (FSMCall, error) {
 	}
 	if strings.HasPrefix(data, importTokenDML) {
 		fsm.state = importStateDML
-		fsm.retentionPolicy = "autogen"
-		return FSMCallEmpty, nil
+		return fsm.switchContext(fsm.database, "autogen"), nil
 	}
 	switch fsm.state {
 	case importStateDDL:
@@ -350,12 +513,10 @@ func (fsm *ImportFileFSM) processLineProtocol(data string) (FSMCall, error) {
 		}, nil
 	case importStateDML:
 		if strings.HasPrefix(data, importTokenDatabase) {
-			fsm.database = strings.TrimSpace(strings.Split(data, ":")[1])
-			return FSMCallEmpty, nil
+			return fsm.switchContext(strings.TrimSpace(strings.Split(data, ":")[1]), fsm.retentionPolicy), nil
 		}
 		if strings.HasPrefix(data, importTokenRetentionPolicy) {
-			fsm.retentionPolicy = strings.TrimSpace(strings.Split(data, ":")[1])
-			return FSMCallEmpty, nil
+			return fsm.switchContext(fsm.database, strings.TrimSpace(strings.Split(data, ":")[1])), nil
 		}
 		if strings.HasPrefix(data, "#") { // skip line with prefix #
 			return FSMCallEmpty, nil
@@ -370,16 +531,26 @@ func (fsm *ImportFileFSM) processLineProtocol(data string) (FSMCall, error) {
 				return errors.New("database is required, make sure `# CONTEXT-DATABASE:` token is exist")
 			}
 
-			command.fsm.batchLPBuffer = append(command.fsm.batchLPBuffer, data)
-			if len(command.fsm.batchLPBuffer) < command.cfg.BatchSize {
-				return nil
-			}
-			return command.excuteByLPBuffer(ctx)
+			command.bufferLines(data)
+			return nil
 		}, nil
 	}
 	return FSMCallEmpty, nil
 }
 
+// switchContext changes the database and the retention policy of the following lines, the lines
+// buffered for the previous ones are sent to the writers first
+func (fsm *ImportFileFSM) switchContext(database, retentionPolicy string) FSMCall {
+	return func(ctx context.Context, command *ImportCommand) error {
+		if database != fsm.database || retentionPolicy != fsm.retentionPolicy {
+			command.flush()
+		}
+		fsm.database = database
+		fsm.retentionPolicy = retentionPolicy
+		return nil
+	}
+}
+
 func (fsm *ImportFileFSM) processCSV(data []string) (FSMCall, error) {
 	if len(data) == 0 {
 		return FSMCallEmpty, nil
@@ -415,6 +586,9 @@ func (fsm *ImportFileFSM) processCSV(data []string) (FSMCall, error) {
 			if len(data) > 0 {
 				data[0] = strings.TrimPrefix(data[0], "\ufeff") // jump BOM
 			}
+			if command.rejects != nil {
+				command.rejects.header = data
+			}
 
 			for idx, datum := range data { // column name
 				_, ok := fsm.tagMap[datum]
@@ -490,113 +664,100 @@ func (fsm *ImportFileFSM) processCSV(data []string) (FSMCall, error) {
 				point.Fields[field.Name] = data[field.Pos]
 			}
 
-			command.fsm.batchPointBuffer = append(command.fsm.batchPointBuffer, point)
-
-			if len(command.fsm.batchPointBuffer) < command.cfg.BatchSize { // continue collect data
-				return nil
-			}
-			// consumption data, if data is full
-			return command.executeByPointBuffer(ctx)
+			command.bufferPoint(point, data)
+			return nil
 		}, nil
 	}
 	return FSMCallEmpty, nil
 }
 
-func (c *ImportCommand) excuteByLPBuffer(ctx context.Context) error {
-	var err error
-	defer func() {
-		if len(c.fsm.batchLPBuffer) <= c.cfg.BatchSize {
-			c.fsm.batchLPBuffer = c.fsm.batchLPBuffer[:0]
-		} else { // more than one batch
-			c.fsm.batchLPBuffer = c.fsm.batchLPBuffer[c.cfg.BatchSize:]
-		}
-	}()
-	var lines = strings.Join(c.fsm.batchLPBuffer[:min(c.cfg.BatchSize, len(c.fsm.batchLPBuffer))], "\n")
-
-	if c.cfg.ColumnWrite {
-		var builderName = c.fsm.database + "." + c.fsm.retentionPolicy
-		builder, ok := builderEntities[builderName]
-		if !ok {
-			builder, err = opengemini.NewWriteRequestBuilder(c.fsm.database, c.fsm.retentionPolicy)
-			if err != nil {
-				return err
-			}
-			builderEntities[builderName] = builder
-		}
-		parser := core.NewLineProtocolParser(lines)
-		points, err := parser.Parse(c.cfg.TimeMultiplier)
-		if err != nil {
-			return err
-		}
-		var recordBuilder = make(map[string]opengemini.RecordBuilder)
-		var recordLines []opengemini.RecordLine
-		for _, point := range points {
-			rb, ok := recordBuilder[point.Measurement]
-			if !ok {
-				rb, err = opengemini.NewRecordBuilder(point.Measurement)
-				if err != nil {
-					return err
-				}
-				recordBuilder[point.Measurement] = rb
-			}
-			newLine := rb.NewLine()
-			for key, value := range point.Tags {
-				newLine.AddTag(key, value)
-			}
-			for key, value := range point.Fields {
-				newLine.AddField(key, value)
-			}
-			recordLines = append(recordLines, newLine.Build(point.Timestamp))
+// bufferLines adds the lines to the batches of their series, a full batch is sent to its writer
+func (c *ImportCommand) bufferLines(lines ...string) {
+	if c.fsm.batchLPBuffer == nil {
+		c.fsm.batchLPBuffer = make([][]string, len(c.pipeline.shards))
+		c.fsm.batchOffsets = make([]int64, len(c.pipeline.shards))
+	}
+	for _, line := range lines {
+		shard := c.shardOf(lineSeriesKey(line))
+		if shard < len(c.fsm.resumeOffsets) && c.fsm.lineOffset < c.fsm.resumeOffsets[shard] {
+			continue
 		}
-		request, err := builder.Authenticate(c.cfg.Username, c.cfg.Password).AddRecord(recordLines...).Build()
-		if err != nil {
-			return err
+		if len(c.fsm.batchLPBuffer[shard]) == 0 {
+			c.fsm.batchOffsets[shard] = c.fsm.lineOffset
 		}
-		response, err := c.writeClient.Write(ctx, request)
-		if err != nil {
-			return err
-		}
-		switch response.Code {
-		case 0:
-			return nil
-		case 1:
-			return fmt.Errorf("write failed, code: %d, partial write failure", response.GetCode())
-		case 2:
-			return fmt.Errorf("write failed, code: %d, write failure", response.GetCode())
-		default:
-			return fmt.Errorf("unexpected response code: %d", response.Code)
+		c.fsm.batchLPBuffer[shard] = append(c.fsm.batchLPBuffer[shard], line)
+		if len(c.fsm.batchLPBuffer[shard]) >= c.cfg.BatchSize {
+			c.flushShard(shard)
 		}
-	} else {
-		err = c.httpClient.Write(ctx, c.fsm.database, c.fsm.retentionPolicy, lines, c.cfg.Precision)
 	}
-	return err
 }
 
-func (c *ImportCommand) executeByPointBuffer(ctx context.Context) error {
-	var err error
-	defer func() {
-		c.fsm.batchPointBuffer = c.fsm.batchPointBuffer[:0]
-	}()
-	var builderName = c.fsm.database + "." + c.fsm.retentionPolicy
-	builder, ok := builderEntities[builderName]
-	if !ok {
-		var buildRequestErr error
-		builder, buildRequestErr = opengemini.NewWriteRequestBuilder(c.fsm.database, c.fsm.retentionPolicy)
-		if buildRequestErr != nil {
-			err = errors.Join(err, buildRequestErr)
+// bufferPoint adds the point parsed from the csv row to the batch of its series, only the column
+// write protocol writes points
+func (c *ImportCommand) bufferPoint(point *opengemini.Point, row []string) {
+	if c.fsm.batchPointBuffer == nil {
+		c.fsm.batchPointBuffer = make([][]*opengemini.Point, len(c.pipeline.shards))
+		c.fsm.batchRowBuffer = make([][][]string, len(c.pipeline.shards))
+	}
+	shard := c.shardOf(pointSeriesKey(point))
+	c.fsm.batchPointBuffer[shard] = append(c.fsm.batchPointBuffer[shard], point)
+	if c.rejects != nil {
+		c.fsm.batchRowBuffer[shard] = append(c.fsm.batchRowBuffer[shard], row)
+	}
+	if len(c.fsm.batchPointBuffer[shard]) >= c.cfg.BatchSize {
+		c.flushShard(shard)
+	}
+}
+
+// flush sends the batches being filled to the writers
+func (c *ImportCommand) flush() {
+	for shard := range c.pipeline.shards {
+		c.flushShard(shard)
+	}
+}
+
+func (c *ImportCommand) flushShard(shard int) {
+	var batch = &importBatch{
+		database:        c.fsm.database,
+		retentionPolicy: c.fsm.retentionPolicy,
+		columnWrite:     c.cfg.ColumnWrite,
+	}
+	if shard < len(c.fsm.batchLPBuffer) {
+		batch.lines = c.fsm.batchLPBuffer[shard]
+		batch.offset = c.fsm.batchOffsets[shard]
+		c.fsm.batchLPBuffer[shard] = nil
+	}
+	if shard < len(c.fsm.batchPointBuffer) {
+		batch.points = c.fsm.batchPointBuffer[shard]
+		batch.rows = c.fsm.batchRowBuffer[shard]
+		c.fsm.batchPointBuffer[shard] = nil
+		c.fsm.batchRowBuffer[shard] = nil
+	}
+	if batch.size() == 0 {
+		return
+	}
+	c.send(shard, batch)
+}
+
+// writeColumns writes the batch by the column write protocol, the line protocol is parsed first
+func (c *ImportCommand) writeColumns(ctx context.Context, batch *importBatch) error {
+	var points = batch.points
+	if len(batch.lines) != 0 {
+		parser := core.NewLineProtocolParser(joinLines(batch.lines))
+		parsed, err := parser.Parse(c.cfg.TimeMultiplier)
+		if err != nil {
 			return err
 		}
-		builderEntities[builderName] = builder
+		points = append(points, parsed...)
 	}
 	var recordBuilder = make(map[string]opengemini.RecordBuilder)
 	var recordLines []opengemini.RecordLine
-	for _, point := range c.fsm.batchPointBuffer {
+	for _, point := range points {
 		rb, ok := recordBuilder[point.Measurement]
 		if !ok {
-			var recordBuilderErr error
-			rb, recordBuilderErr = opengemini.NewRecordBuilder(point.Measurement)
-			if recordBuilderErr != nil {
-				err = errors.Join(err, recordBuilderErr)
+			var err error
+			rb, err = opengemini.NewRecordBuilder(point.Measurement)
+			if err != nil {
 				return err
 			}
 			recordBuilder[point.Measurement] = rb
@@ -610,29 +771,30 @@ func (c *ImportCommand) executeByPointBuffer(ctx context.Context) error {
 		}
 		recordLines = append(recordLines, newLine.Build(point.Timestamp))
 	}
-	var buildErr error
-	request, buildErr := builder.Authenticate(c.cfg.Username, c.cfg.Password).AddRecord(recordLines...).Build()
-	if buildErr != nil {
-		err = errors.Join(err, buildErr)
+	request, err := builderEntities.build(batch.database, batch.retentionPolicy, c.cfg.Username, c.cfg.Password, recordLines)
+	if err != nil {
 		return err
 	}
-	response, writeErr := c.writeClient.Write(ctx, request)
-	if writeErr != nil {
-		err = errors.Join(err, writeErr)
+	response, err := c.writeClient.Write(ctx, request)
+	if err != nil {
 		return err
 	}
 	switch response.Code {
 	case 0:
-		return err
+		return nil
 	case 1:
-		return fmt.Errorf("%w\nwrite failed, code: %d, partial write failure", err, response.GetCode())
+		return fmt.Errorf("write failed, code: %d, %w", response.GetCode(), errPartialWrite)
 	case 2:
-		return fmt.Errorf("%w\nwrite failed, code: %d, write failure", err, response.GetCode())
+		return fmt.Errorf("write failed, code: %d, write failure", response.GetCode())
 	default:
-		return fmt.Errorf("%w\nunexpected response code: %d", err, response.Code)
+		return fmt.Errorf("unexpected response code: %d", response.Code)
 	}
 }
 
+func joinLines(lines []string) string {
+	return strings.Join(lines, "\n")
+}
+
 func (c *ImportCommand) parseTimestamp2Int64(s string) int64 {
 	tsp := int64(fastfloat.ParseBestEffort(s))
 	return tsp * c.cfg.TimeMultiplier
@@ -0,0 +1,126 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+)
+
+// importFiles expands the --path values to the files to import, in the order they are given. A
+// glob pattern is expanded to the paths it matches and a directory to the files under it, both in
+// lexical order. The hidden files and directories under a directory are skipped, and so is the
+// reject file, which may be written into the imported directory. - is stdin.
+func importFiles(paths []string, rejectFile string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("--path is required")
+	}
+	if rejectFile != "" {
+		rejectFile, _ = filepath.Abs(rejectFile)
+	}
+	var files []string
+	for _, path := range paths {
+		if path == importStdin {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, fmt.Errorf("bad --path pattern %s: %w", path, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no such file or directory: %s", path)
+		}
+		for _, match := range matches {
+			err = filepath.WalkDir(match, func(file string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if file != match && strings.HasPrefix(entry.Name(), ".") {
+					if entry.IsDir() {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if abs, _ := filepath.Abs(file); entry.IsDir() || abs == rejectFile {
+					return nil
+				}
+				files = append(files, file)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no files to import under --path")
+	}
+	return files, nil
+}
+
+// importFileResult is the outcome of one of the imported files
+type importFileResult struct {
+	path         string
+	points       int64
+	failedPoints int64
+	elapsed      time.Duration
+	err          error
+}
+
+func (c *ImportCommand) fileResult(path string, err error) importFileResult {
+	var result = importFileResult{path: path, err: err}
+	if c.pipeline != nil {
+		stats := &c.pipeline.stats
+		result.points = stats.points.Load()
+		result.failedPoints = stats.failedPoints.Load()
+		result.elapsed = time.Since(stats.start)
+	}
+	return result
+}
+
+// writeFileResults writes the table of the points imported from every file
+func writeFileResults(w io.Writer, results []importFileResult) {
+	table := tablewriter.NewTable(w,
+		tablewriter.WithRenderer(
+			renderer.NewBlueprint(tw.Rendition{Symbols: tw.NewSymbols(tw.StyleASCII)})),
+		tablewriter.WithEastAsian(false),
+	)
+	table.Header([]string{"file", "points", "failed points", "elapsed", "result"})
+	for _, result := range results {
+		status := "ok"
+		if result.err != nil {
+			status = result.err.Error()
+		}
+		_ = table.Append([]string{
+			result.path,
+			strconv.FormatInt(result.points, 10),
+			strconv.FormatInt(result.failedPoints, 10),
+			result.elapsed.Round(time.Millisecond).String(),
+			status,
+		})
+	}
+	_ = table.Render()
+}
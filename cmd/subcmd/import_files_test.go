@@ -0,0 +1,116 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcmd
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestImportFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"dump/b.txt":       "",
+		"dump/a.txt":       "",
+		"dump/sub/c.txt":   "",
+		"day-02.txt":       "",
+		"day-01.txt":       "",
+		"other/notes.json": "",
+		// the hidden files and the reject file of a previous import are not imported
+		"dump/.hidden.txt":  "",
+		"dump/.git/config":  "",
+		"dump/rejected.txt": "",
+	})
+
+	rejectFile := filepath.Join(dir, "dump", "rejected.txt")
+	files, err := importFiles([]string{filepath.Join(dir, "day-*.txt"), "-", filepath.Join(dir, "dump")}, rejectFile)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "day-01.txt"),
+		filepath.Join(dir, "day-02.txt"),
+		"-",
+		filepath.Join(dir, "dump", "a.txt"),
+		filepath.Join(dir, "dump", "b.txt"),
+		filepath.Join(dir, "dump", "sub", "c.txt"),
+	}, files)
+	// a hidden file is imported when it is given explicitly
+	files, err = importFiles([]string{filepath.Join(dir, "dump", ".hidden.txt")}, rejectFile)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "dump", ".hidden.txt")}, files)
+
+	_, err = importFiles(nil, "")
+	require.ErrorContains(t, err, "--path is required")
+	_, err = importFiles([]string{filepath.Join(dir, "missing-*.txt")}, "")
+	require.ErrorContains(t, err, "no such file or directory")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "empty"), 0755))
+	_, err = importFiles([]string{filepath.Join(dir, "empty")}, "")
+	require.ErrorContains(t, err, "no files to import")
+}
+
+func TestImportCommand_RunFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"2.txt": "# DML\n# CONTEXT-DATABASE: db1\nmem value=1 1\nbad value=2 2\n",
+		"1.txt": "# DML\n# CONTEXT-DATABASE: db0\ncpu value=1 1\nbad value=2 2\n",
+	})
+	rejectFile := filepath.Join(t.TempDir(), "rejected.txt")
+	c, writes := newTestImportCommand(t, &ImportConfig{BatchSize: 1, Workers: 1, RejectFile: rejectFile}, func(lines []string) int {
+		if strings.HasPrefix(lines[0], "bad") {
+			return http.StatusBadRequest
+		}
+		return http.StatusNoContent
+	})
+	c.cfg.Paths = []string{dir}
+	err := c.Run(c.cfg)
+	require.ErrorContains(t, err, "import "+filepath.Join(dir, "1.txt")+" failed: 1 points in 1 batches rejected")
+	require.ErrorContains(t, err, "import "+filepath.Join(dir, "2.txt")+" failed")
+
+	require.Equal(t, []recordedWrite{
+		{database: "db0", lines: []string{"cpu value=1 1"}},
+		{database: "db1", lines: []string{"mem value=1 1"}},
+	}, writes())
+	// the rejected batches of every file are collected in the reject file
+	rejected, err := os.ReadFile(rejectFile)
+	require.NoError(t, err)
+	require.Equal(t, 2, strings.Count(string(rejected), "# ERROR:"))
+	require.Contains(t, string(rejected), "# CONTEXT-DATABASE: db0\n")
+	require.Contains(t, string(rejected), "# CONTEXT-DATABASE: db1\n")
+}
+
+func TestWriteFileResults(t *testing.T) {
+	var buf bytes.Buffer
+	writeFileResults(&buf, []importFileResult{
+		{path: "a.txt", points: 10},
+		{path: "b.txt", points: 3, failedPoints: 2, err: errors.New("2 points in 1 batches rejected")},
+	})
+	require.Regexp(t, `a\.txt\s*\|\s*10\s*\|\s*0\s*\|.*\|\s*ok`, buf.String())
+	require.Regexp(t, `b\.txt\s*\|\s*3\s*\|\s*2\s*\|.*\|\s*2 points in 1 batches rejected`, buf.String())
+}
@@ -0,0 +1,323 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcmd
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+	"github.com/openGemini/opengemini-client-go/proto"
+)
+
+// importBatch is the data written by one request, it keeps the destination it was parsed for
+// since the parser moves on to other contexts while the batch is waiting for a writer
+type importBatch struct {
+	database        string
+	retentionPolicy string
+	columnWrite     bool
+	// lines are the line protocol of the text and json formats, points are parsed from csv
+	lines  []string
+	points []*opengemini.Point
+	// rows are the csv rows of the points, kept for the --reject-file only
+	rows [][]string
+	// offset is where the first line of the batch starts in the line protocol file
+	offset int64
+}
+
+func (b *importBatch) size() int {
+	return len(b.lines) + len(b.points)
+}
+
+// importPipeline writes the batches by the writer goroutines, the batches of a series always go
+// to the same writer, so the points of a series are written in the order of the file
+type importPipeline struct {
+	shards []chan *importBatch
+	// inflight limits the batches parsed but not written yet, it bounds the memory
+	inflight chan struct{}
+	wg       sync.WaitGroup
+	stats    importStats
+
+	// pending are the batches sent but not acknowledged yet and their writers, the failed batches
+	// without the --reject-file stay pending, so the checkpoint never moves past them
+	mu      sync.Mutex
+	pending map[*importBatch]int
+}
+
+// importStats counts the points and batches written by all the writers
+type importStats struct {
+	start         time.Time
+	points        atomic.Int64
+	batches       atomic.Int64
+	failedPoints  atomic.Int64
+	failedBatches atomic.Int64
+
+	// measurements are the points written per measurement
+	mu           sync.Mutex
+	measurements map[string]int64
+}
+
+func (c *ImportCommand) startPipeline(ctx context.Context) {
+	workers := max(c.cfg.Workers, 1)
+	inflight := c.cfg.MaxInflightBatches
+	if inflight <= 0 {
+		inflight = 2 * workers
+	}
+	c.pipeline = &importPipeline{
+		shards:   make([]chan *importBatch, workers),
+		inflight: make(chan struct{}, inflight),
+		pending:  make(map[*importBatch]int),
+	}
+	c.pipeline.stats.start = time.Now()
+	if c.cfg.RejectFile != "" && c.rejects == nil {
+		c.rejects = &rejectWriter{path: c.cfg.RejectFile, format: c.cfg.Format}
+	}
+	for i := range c.pipeline.shards {
+		shard := make(chan *importBatch, inflight)
+		c.pipeline.shards[i] = shard
+		c.pipeline.wg.Add(1)
+		go func() {
+			defer c.pipeline.wg.Done()
+			for batch := range shard {
+				if c.writeBatch(ctx, batch) {
+					c.pipeline.acknowledge(batch)
+				}
+				<-c.pipeline.inflight
+			}
+		}()
+	}
+}
+
+// stopPipeline waits for the batches sent to the writers and logs the throughput, it fails if
+// any batch is rejected
+func (c *ImportCommand) stopPipeline() error {
+	for _, shard := range c.pipeline.shards {
+		close(shard)
+	}
+	c.pipeline.wg.Wait()
+	c.progress.stop()
+
+	stats := &c.pipeline.stats
+	elapsed := time.Since(stats.start)
+	slog.Info("import summary",
+		"points", stats.points.Load(),
+		"batches", stats.batches.Load(),
+		"failed_points", stats.failedPoints.Load(),
+		"failed_batches", stats.failedBatches.Load(),
+		"elapsed", elapsed.Round(time.Millisecond),
+		"points_per_second", int64(float64(stats.points.Load())/max(elapsed.Seconds(), 0.001)),
+	)
+	if err := c.rejects.close(); err != nil {
+		return err
+	}
+	if failed := stats.failedBatches.Load(); failed != 0 {
+		if c.rejects != nil {
+			return fmt.Errorf("%d points in %d batches rejected, they are written to %s", stats.failedPoints.Load(), failed, c.rejects.path)
+		}
+		return fmt.Errorf("%d points in %d batches rejected", stats.failedPoints.Load(), failed)
+	}
+	return nil
+}
+
+// send hands the batch to the writer of the shard, it blocks while too many batches are in flight
+func (c *ImportCommand) send(shard int, batch *importBatch) {
+	c.pipeline.mu.Lock()
+	c.pipeline.pending[batch] = shard
+	c.pipeline.mu.Unlock()
+	c.pipeline.inflight <- struct{}{}
+	c.pipeline.shards[shard] <- batch
+}
+
+func (p *importPipeline) acknowledge(batch *importBatch) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.pending, batch)
+}
+
+// oldestPending is the pending batch starting first in the file, nil if all are acknowledged
+func (p *importPipeline) oldestPending() *importBatch {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var oldest *importBatch
+	for batch := range p.pending {
+		if oldest == nil || batch.offset < oldest.offset {
+			oldest = batch
+		}
+	}
+	return oldest
+}
+
+// lowerToPending moves the offsets of the writers back to their oldest pending batches
+func (p *importPipeline) lowerToPending(offsets []int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for batch, shard := range p.pending {
+		offsets[shard] = min(offsets[shard], batch.offset)
+	}
+}
+
+// writeBatch writes the batch and retries the transient failures, the writer waits for the retries
+// to keep the order of the series. A batch failed after the retries is written to the --reject-file.
+// It reports whether the batch is acknowledged, that is written to the server or to the reject file.
+// The retries stop waiting when ctx is done.
+func (c *ImportCommand) writeBatch(ctx context.Context, batch *importBatch) bool {
+	err := c.writeOnce(ctx, batch)
+	for retry := 1; err != nil && ctx.Err() == nil && retry <= c.cfg.Retries && isTransient(err); retry++ {
+		delay := c.cfg.retryDelay(retry)
+		slog.Warn("write batch failed, retrying", "retry", retry, "delay", delay, "reason", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+			err = c.writeOnce(ctx, batch)
+		}
+	}
+	if err != nil && ctx.Err() != nil {
+		// the import is interrupted, the batch is neither written nor rejected and stays pending
+		return false
+	}
+	stats := &c.pipeline.stats
+	if err != nil {
+		slog.Error("write batch failed", "database", batch.database, "retention_policy", batch.retentionPolicy,
+			"points", batch.size(), "reason", err)
+		stats.failedPoints.Add(int64(batch.size()))
+		stats.failedBatches.Add(1)
+		if c.rejects == nil {
+			return false
+		}
+		if rerr := c.rejects.write(batch, err); rerr != nil {
+			slog.Error("write reject file failed", "file", c.rejects.path, "reason", rerr)
+			return false
+		}
+		return true
+	}
+	stats.points.Add(int64(batch.size()))
+	stats.batches.Add(1)
+	stats.countMeasurements(batch)
+	return true
+}
+
+func (c *ImportCommand) writeOnce(ctx context.Context, batch *importBatch) error {
+	if batch.columnWrite {
+		return c.writeColumns(ctx, batch)
+	}
+	return c.httpClient.Write(ctx, batch.database, batch.retentionPolicy, joinLines(batch.lines), c.cfg.Precision)
+}
+
+// shardOf picks the writer of the series, seriesKey is the measurement and the tags
+func (c *ImportCommand) shardOf(seriesKey string) int {
+	shards := len(c.pipeline.shards)
+	if shards == 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(seriesKey))
+	return int(h.Sum32() % uint32(shards))
+}
+
+// lineSeriesKey is the measurement and the tags of the line protocol, which end at the first
+// space not escaped by a backslash. The tags are sorted like pointSeriesKey, so a series written
+// with its tags in another order goes to the same writer
+func lineSeriesKey(line string) string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case ',':
+			parts = append(parts, line[start:i])
+			start = i + 1
+		case ' ':
+			return sortedSeriesKey(line[:i], append(parts, line[start:i]))
+		}
+	}
+	return sortedSeriesKey(line, append(parts, line[start:]))
+}
+
+// sortedSeriesKey joins the measurement and the tags split from the key with the tags sorted, the
+// key is returned as it is when its tags are already in order
+func sortedSeriesKey(key string, parts []string) string {
+	tags := parts[1:]
+	if slices.IsSorted(tags) {
+		return key
+	}
+	slices.Sort(tags)
+	return strings.Join(parts, ",")
+}
+
+func pointSeriesKey(point *opengemini.Point) string {
+	keys := make([]string, 0, len(point.Tags))
+	for key := range point.Tags {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	seriesKey := point.Measurement
+	for _, key := range keys {
+		seriesKey += "," + key + "=" + point.Tags[key]
+	}
+	return seriesKey
+}
+
+// writeRequestBuilders caches a WriteRequestBuilder per database and retention policy. A builder
+// collects the records until Build, so it is locked while a writer builds its request.
+type writeRequestBuilders struct {
+	mu       sync.Mutex
+	builders map[string]*lockedWriteRequestBuilder
+}
+
+type lockedWriteRequestBuilder struct {
+	mu      sync.Mutex
+	builder opengemini.WriteRequestBuilder
+}
+
+func newWriteRequestBuilders() *writeRequestBuilders {
+	return &writeRequestBuilders{builders: make(map[string]*lockedWriteRequestBuilder)}
+}
+
+func (w *writeRequestBuilders) get(database, retentionPolicy string) (*lockedWriteRequestBuilder, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var builderName = database + "." + retentionPolicy
+	entity, ok := w.builders[builderName]
+	if !ok {
+		builder, err := opengemini.NewWriteRequestBuilder(database, retentionPolicy)
+		if err != nil {
+			return nil, err
+		}
+		entity = &lockedWriteRequestBuilder{builder: builder}
+		w.builders[builderName] = entity
+	}
+	return entity, nil
+}
+
+// build creates the write request of the records for the database and retention policy
+func (w *writeRequestBuilders) build(database, retentionPolicy, username, password string, records []opengemini.RecordLine) (*proto.WriteRequest, error) {
+	entity, err := w.get(database, retentionPolicy)
+	if err != nil {
+		return nil, err
+	}
+	entity.mu.Lock()
+	defer entity.mu.Unlock()
+	return entity.builder.Authenticate(username, password).AddRecord(records...).Build()
+}
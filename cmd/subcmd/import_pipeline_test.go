@@ -0,0 +1,132 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/openGemini-cli/core"
+)
+
+type recordedWrite struct {
+	database string
+	lines    []string
+}
+
+// newTestImportCommand imports to a server recording the written lines, status answers the write
+// of the lines, nil accepts all
+func newTestImportCommand(t *testing.T, cfg *ImportConfig, status func(lines []string) int) (*ImportCommand, func() []recordedWrite) {
+	var mu sync.Mutex
+	var writes []recordedWrite
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		lines := strings.Split(string(body), "\n")
+		mu.Lock()
+		defer mu.Unlock()
+		if status != nil {
+			if code := status(lines); code != http.StatusNoContent {
+				w.WriteHeader(code)
+				return
+			}
+		}
+		writes = append(writes, recordedWrite{database: r.URL.Query().Get("db"), lines: lines})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	address, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(address.Port())
+	require.NoError(t, err)
+	if cfg.CommandLineConfig == nil {
+		cfg.CommandLineConfig = &core.CommandLineConfig{}
+	}
+	cfg.Host, cfg.Port, cfg.Timeout = address.Hostname(), port, 1000
+	if cfg.Format == "" {
+		cfg.Format = importFormatLineProtocol
+	}
+	httpClient, err := core.NewHttpClient(cfg.CommandLineConfig)
+	require.NoError(t, err)
+	c := &ImportCommand{cfg: cfg, httpClient: httpClient, fsm: new(ImportFileFSM)}
+	return c, func() []recordedWrite {
+		mu.Lock()
+		defer mu.Unlock()
+		return writes
+	}
+}
+
+func TestImportPipeline(t *testing.T) {
+	var input strings.Builder
+	input.WriteString("# DML\n# CONTEXT-DATABASE: db0\n")
+	for i := 0; i < 50; i++ {
+		_, _ = fmt.Fprintf(&input, "cpu,host=server%02d value=%d %d\n", i%5, i, i)
+	}
+	input.WriteString("# CONTEXT-DATABASE: db1\nmem,host=server00 value=1 1")
+
+	c, writes := newTestImportCommand(t, &ImportConfig{BatchSize: 3, Workers: 3, MaxInflightBatches: 2}, nil)
+	c.startPipeline(context.Background())
+	require.NoError(t, c.parse(context.Background(), strings.NewReader(input.String())))
+	require.NoError(t, c.stopPipeline())
+
+	require.EqualValues(t, 51, c.pipeline.stats.points.Load())
+	require.Zero(t, c.pipeline.stats.failedBatches.Load())
+
+	// the points of every series arrive in the order of the file
+	last := make(map[string]int)
+	for _, write := range writes() {
+		for _, line := range write.lines {
+			if strings.HasPrefix(line, "mem") {
+				require.Equal(t, "db1", write.database)
+				continue
+			}
+			require.Equal(t, "db0", write.database)
+			var host string
+			var value, timestamp int
+			_, err := fmt.Sscanf(line, "cpu,host=%s value=%d %d", &host, &value, &timestamp)
+			require.NoError(t, err, line)
+			previous, ok := last[host]
+			require.True(t, !ok || previous < value, line)
+			last[host] = value
+		}
+	}
+	require.Len(t, last, 5)
+}
+
+func TestLineSeriesKey(t *testing.T) {
+	require.Equal(t, "cpu,host=a", lineSeriesKey("cpu,host=a value=1 1"))
+	require.Equal(t, `cpu,host=a\ b`, lineSeriesKey(`cpu,host=a\ b value=1`))
+	require.Equal(t, "cpu", lineSeriesKey("cpu"))
+	require.Equal(t, `cpu,a=1,b=x\,y,c=3`, lineSeriesKey(`cpu,c=3,a=1,b=x\,y value=1`))
+	// the series goes to the same writer whatever the order of its tags
+	c := &ImportCommand{pipeline: &importPipeline{shards: make([]chan *importBatch, 7)}}
+	point := &opengemini.Point{Measurement: "cpu", Tags: map[string]string{"region": "east", "host": "a"}}
+	for _, line := range []string{"cpu,host=a,region=east value=1", "cpu,region=east,host=a value=1"} {
+		require.Equal(t, pointSeriesKey(point), lineSeriesKey(line))
+		require.Equal(t, c.shardOf(pointSeriesKey(point)), c.shardOf(lineSeriesKey(line)))
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/vbauerster/mpb/v7"
+	"github.com/vbauerster/mpb/v7/decor"
+	"golang.org/x/term"
+)
+
+var (
+	// importProgressRefresh is how often the progress bar is redrawn
+	importProgressRefresh = 200 * time.Millisecond
+	// importProgressLogInterval is how often the progress is logged when stderr is not a terminal
+	importProgressLogInterval = 10 * time.Second
+)
+
+// importProgress reports the bytes of the file read by the parser and the points written by the
+// pipeline, by a progress bar on a terminal and by log lines otherwise
+type importProgress struct {
+	stats *importStats
+	total int64
+	// resumed is the offset the import started at, it does not count in the rate
+	resumed int64
+	read    atomic.Int64
+	start   time.Time
+	// summary receives the table of the points written per measurement
+	summary io.Writer
+
+	bars *mpb.Progress
+	bar  *mpb.Bar
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// startProgress reports the import of total bytes from the offset, it reports to the bar if
+// stderr is a terminal
+func (c *ImportCommand) startProgress(total, offset int64) {
+	p := &importProgress{
+		stats:   &c.pipeline.stats,
+		total:   total,
+		resumed: offset,
+		start:   time.Now(),
+		summary: os.Stdout,
+		done:    make(chan struct{}),
+	}
+	p.read.Store(offset)
+	c.progress = p
+
+	interval := importProgressLogInterval
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		interval = importProgressRefresh
+		p.bars = mpb.New(mpb.WithOutput(os.Stderr), mpb.WithWidth(60), mpb.WithRefreshRate(importProgressRefresh))
+		p.bar = p.bars.New(total,
+			mpb.BarStyle().Lbound("[").Filler("=").Tip(">").Padding("-").Rbound("]"),
+			mpb.PrependDecorators(
+				decor.Name("Importing Data:", decor.WC{W: 16, C: decor.DidentRight}),
+				decor.Counters(decor.UnitKiB, "% .1f / % .1f", decor.WC{W: 22, C: decor.DidentRight}),
+			),
+			mpb.AppendDecorators(
+				decor.Percentage(decor.WC{W: 5}),
+				decor.Any(func(decor.Statistics) string { return " " + p.describe() }),
+			),
+		)
+		p.bar.SetCurrent(offset)
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				p.report()
+			}
+		}
+	}()
+}
+
+// reader counts the bytes read from the file
+func (p *importProgress) reader(r io.Reader) io.Reader {
+	if p == nil {
+		return r
+	}
+	return &progressReader{r: r, read: &p.read}
+}
+
+func (p *importProgress) report() {
+	if p.bar != nil {
+		p.bar.SetCurrent(p.read.Load())
+		return
+	}
+	elapsed := time.Since(p.start)
+	slog.Info("import progress",
+		"read_bytes", p.read.Load(),
+		"total_bytes", p.total,
+		"points", p.stats.points.Load(),
+		"points_per_second", int64(float64(p.stats.points.Load())/max(elapsed.Seconds(), 0.001)),
+		"batches", p.stats.batches.Load(),
+		"failed_batches", p.stats.failedBatches.Load(),
+		"eta", p.eta(),
+	)
+}
+
+// describe is the rate, the batches and the remaining time shown after the bar
+func (p *importProgress) describe() string {
+	elapsed := time.Since(p.start)
+	return fmt.Sprintf("%d points/s | batches %d ok, %d failed | ETA %s",
+		int64(float64(p.stats.points.Load())/max(elapsed.Seconds(), 0.001)),
+		p.stats.batches.Load(), p.stats.failedBatches.Load(), p.eta())
+}
+
+// eta estimates the time to read the rest of the file at the average rate so far
+func (p *importProgress) eta() string {
+	read := p.read.Load()
+	rate := float64(read-p.resumed) / time.Since(p.start).Seconds()
+	if p.total <= 0 || rate <= 0 {
+		return "-"
+	}
+	remaining := time.Duration(float64(max(p.total-read, 0)) / rate * float64(time.Second))
+	return remaining.Round(time.Second).String()
+}
+
+// stop completes the bar, or aborts it if the file was not read to the end, then writes the
+// summary table
+func (p *importProgress) stop() {
+	if p == nil {
+		return
+	}
+	close(p.done)
+	p.wg.Wait()
+	if p.bar != nil {
+		if read := p.read.Load(); p.total <= 0 || read >= p.total {
+			p.bar.SetTotal(read, true)
+		} else {
+			p.bar.SetCurrent(read)
+			p.bar.Abort(false)
+		}
+		p.bars.Wait()
+	}
+	p.stats.writeMeasurements(p.summary)
+}
+
+type progressReader struct {
+	r    io.Reader
+	read *atomic.Int64
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.read.Add(int64(n))
+	return n, err
+}
+
+// countMeasurements adds the points of the written batch to their measurements
+func (s *importStats) countMeasurements(batch *importBatch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.measurements == nil {
+		s.measurements = make(map[string]int64)
+	}
+	for _, line := range batch.lines {
+		s.measurements[lineMeasurement(line)]++
+	}
+	for _, point := range batch.points {
+		s.measurements[point.Measurement]++
+	}
+}
+
+// writeMeasurements writes the table of the points written per measurement
+func (s *importStats) writeMeasurements(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.measurements) == 0 {
+		return
+	}
+	names := make([]string, 0, len(s.measurements))
+	for name := range s.measurements {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	table := tablewriter.NewTable(w,
+		tablewriter.WithRenderer(
+			renderer.NewBlueprint(tw.Rendition{Symbols: tw.NewSymbols(tw.StyleASCII)})),
+		tablewriter.WithEastAsian(false),
+	)
+	table.Header([]string{"measurement", "points"})
+	for _, name := range names {
+		_ = table.Append([]string{name, strconv.FormatInt(s.measurements[name], 10)})
+	}
+	_ = table.Render()
+}
+
+// lineMeasurement is the measurement of the line protocol, which ends at the first comma or space
+// not escaped by a backslash
+func lineMeasurement(line string) string {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case ',', ' ':
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportProgress(t *testing.T) {
+	input := "# DML\n# CONTEXT-DATABASE: db0\ncpu,host=a value=1 1\ncpu,host=b value=2 2\nmem value=3 3\n" +
+		"disk\\,io,host=a value=4 4\n"
+	c, _ := newTestImportCommand(t, &ImportConfig{BatchSize: 2, Workers: 2}, nil)
+	c.startPipeline(context.Background())
+	c.startProgress(int64(len(input)), 0)
+	var summary bytes.Buffer
+	c.progress.summary = &summary
+	require.NoError(t, c.parse(context.Background(), c.progress.reader(strings.NewReader(input))))
+	require.NoError(t, c.stopPipeline())
+
+	require.EqualValues(t, len(input), c.progress.read.Load())
+	require.Contains(t, c.progress.describe(), "batches 3 ok, 0 failed")
+	require.Equal(t, "0s", c.progress.eta())
+	table := summary.String()
+	require.Regexp(t, `cpu\s*\|\s*2`, table)
+	require.Regexp(t, `mem\s*\|\s*1`, table)
+	require.Regexp(t, `disk\\,io\s*\|\s*1`, table)
+	require.Less(t, strings.Index(table, "cpu"), strings.Index(table, "mem"))
+}
+
+func TestLineMeasurement(t *testing.T) {
+	require.Equal(t, "cpu", lineMeasurement("cpu,host=a value=1 1"))
+	require.Equal(t, "cpu", lineMeasurement("cpu value=1"))
+	require.Equal(t, `cpu\ load`, lineMeasurement(`cpu\ load,host=a value=1`))
+}
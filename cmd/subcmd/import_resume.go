@@ -0,0 +1,222 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// importCheckpointInterval is how often the checkpoint of the line protocol file is saved
+var importCheckpointInterval = 5 * time.Second
+
+// importCheckpoint is the offset the import of the file continues from and the context of the
+// FSM at that offset, every line before the offset is written or rejected. With several writers
+// the others may be ahead of the offset, ShardOffsets are the first lines not acknowledged by
+// every writer, so their lines written after Offset are not imported twice.
+type importCheckpoint struct {
+	Path            string      `json:"path"`
+	Size            int64       `json:"size"`
+	ModTime         time.Time   `json:"mod_time"`
+	Offset          int64       `json:"offset"`
+	ShardOffsets    []int64     `json:"shard_offsets,omitempty"`
+	State           ImportState `json:"state"`
+	Database        string      `json:"database"`
+	RetentionPolicy string      `json:"retention_policy"`
+	Measurement     string      `json:"measurement"`
+}
+
+// importCheckpointer saves the checkpoints of the imported file to path
+type importCheckpointer struct {
+	path string
+	// file identifies the imported file, a checkpoint of another version of it is refused
+	file  importCheckpoint
+	saved time.Time
+}
+
+// checkpointPath is the checkpoint file of the imported file in ~/.ts-cli, it is named by the
+// hash of the absolute path, so every imported file has its own checkpoint
+func checkpointPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(home, ".ts-cli", "import-"+hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+func loadCheckpoint(path string) (*importCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var checkpoint importCheckpoint
+	if err = json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("parse checkpoint %s failed: %w", path, err)
+	}
+	return &checkpoint, nil
+}
+
+// save replaces the checkpoint file by renaming, an interrupted save keeps the previous checkpoint
+func (cp *importCheckpoint) save(path string) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// openCheckpoint prepares the checkpoints of the file, with --resume the file is moved to the
+// offset of the saved checkpoint and the FSM gets back its context
+func (c *ImportCommand) openCheckpoint(name string, file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	path, err := checkpointPath(name)
+	if err != nil {
+		return err
+	}
+	abs, _ := filepath.Abs(name)
+	c.checkpoints = &importCheckpointer{
+		path: path,
+		file: importCheckpoint{Path: abs, Size: info.Size(), ModTime: info.ModTime()},
+	}
+	if !c.cfg.Resume {
+		return nil
+	}
+
+	checkpoint, err := loadCheckpoint(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		slog.Info("no checkpoint found, import from the beginning", "path", name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if checkpoint.Size != info.Size() || !checkpoint.ModTime.Equal(info.ModTime()) {
+		return fmt.Errorf("%s is changed since its checkpoint was saved, import it without --resume", name)
+	}
+	if _, err = file.Seek(checkpoint.Offset, io.SeekStart); err != nil {
+		return err
+	}
+	c.fsm.state = checkpoint.State
+	c.fsm.database = checkpoint.Database
+	c.fsm.retentionPolicy = checkpoint.RetentionPolicy
+	c.fsm.measurement = checkpoint.Measurement
+	c.fsm.nextOffset = checkpoint.Offset
+	// the lines are assigned to the writers by the hash of their series, which depends on the workers
+	switch {
+	case len(checkpoint.ShardOffsets) == max(c.cfg.Workers, 1):
+		c.fsm.resumeOffsets = checkpoint.ShardOffsets
+	case len(checkpoint.ShardOffsets) > 1:
+		slog.Warn("the checkpoint was saved with other --workers, the lines written after it are imported again",
+			"workers", len(checkpoint.ShardOffsets))
+	}
+	slog.Info("resume import", "path", name, "offset", checkpoint.Offset, "database", checkpoint.Database,
+		"retention_policy", checkpoint.RetentionPolicy)
+	return nil
+}
+
+// checkpoint is the offset of the first line not acknowledged, it is either the first line of a
+// pending batch, of a batch being filled, or the next line of the file
+func (c *ImportCommand) checkpoint() importCheckpoint {
+	var checkpoint = c.checkpoints.file
+	checkpoint.Offset = c.fsm.nextOffset
+	checkpoint.State = c.fsm.state
+	checkpoint.Database = c.fsm.database
+	checkpoint.RetentionPolicy = c.fsm.retentionPolicy
+	checkpoint.Measurement = c.fsm.measurement
+	// the batches being filled are flushed when the context changes, so they share the current one
+	for shard, lines := range c.fsm.batchLPBuffer {
+		if len(lines) != 0 {
+			checkpoint.Offset = min(checkpoint.Offset, c.fsm.batchOffsets[shard])
+		}
+	}
+	if batch := c.pipeline.oldestPending(); batch != nil && batch.offset < checkpoint.Offset {
+		checkpoint.Offset = batch.offset
+		checkpoint.State = importStateDML
+		checkpoint.Database = batch.database
+		checkpoint.RetentionPolicy = batch.retentionPolicy
+	}
+	checkpoint.ShardOffsets = c.shardOffsets()
+	return checkpoint
+}
+
+// shardOffsets are the offsets of the first lines not acknowledged by each writer, the offset of
+// a writer resumed but not reached yet is kept since its lines before are skipped
+func (c *ImportCommand) shardOffsets() []int64 {
+	offsets := make([]int64, len(c.pipeline.shards))
+	for shard := range offsets {
+		offsets[shard] = c.fsm.nextOffset
+		if shard < len(c.fsm.resumeOffsets) {
+			offsets[shard] = max(offsets[shard], c.fsm.resumeOffsets[shard])
+		}
+		if shard < len(c.fsm.batchLPBuffer) && len(c.fsm.batchLPBuffer[shard]) != 0 {
+			offsets[shard] = min(offsets[shard], c.fsm.batchOffsets[shard])
+		}
+	}
+	c.pipeline.lowerToPending(offsets)
+	return offsets
+}
+
+// saveCheckpoint saves the checkpoint once per importCheckpointInterval, or now if force
+func (c *ImportCommand) saveCheckpoint(force bool) {
+	if c.checkpoints == nil || !force && time.Since(c.checkpoints.saved) < importCheckpointInterval {
+		return
+	}
+	checkpoint := c.checkpoint()
+	if err := checkpoint.save(c.checkpoints.path); err != nil {
+		slog.Warn("save checkpoint failed", "file", c.checkpoints.path, "reason", err)
+	}
+	c.checkpoints.saved = time.Now()
+}
+
+// finishCheckpoint removes the checkpoint of the file imported to the end, otherwise it saves the
+// last checkpoint to be resumed from
+func (c *ImportCommand) finishCheckpoint(name string) {
+	if c.checkpoints == nil {
+		return
+	}
+	if c.checkpoint().Offset < c.checkpoints.file.Size {
+		c.saveCheckpoint(true)
+		slog.Info("import is incomplete, run it again with --resume to continue", "path", name,
+			"checkpoint", c.checkpoints.path)
+		return
+	}
+	if err := os.Remove(c.checkpoints.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		slog.Warn("remove checkpoint failed", "file", c.checkpoints.path, "reason", err)
+	}
+}
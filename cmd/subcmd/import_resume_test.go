@@ -0,0 +1,124 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportResume(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var input strings.Builder
+	input.WriteString("# DML\n# CONTEXT-DATABASE: db0\n")
+	for i := 0; i < 10; i++ {
+		_, _ = fmt.Fprintf(&input, "cpu value=%d %d\n", i, i)
+	}
+	input.WriteString("# CONTEXT-DATABASE: db1\nmem value=1 1\n")
+	path := filepath.Join(t.TempDir(), "data.txt")
+	require.NoError(t, os.WriteFile(path, []byte(input.String()), 0644))
+	checkpointFile, err := checkpointPath(path)
+	require.NoError(t, err)
+
+	// the batch of the lines 4 and 5 fails, the import stops the checkpoint before it
+	c, _ := newTestImportCommand(t, &ImportConfig{BatchSize: 2, Workers: 1}, func(lines []string) int {
+		if slices.Contains(lines, "cpu value=4 4") {
+			return http.StatusBadRequest
+		}
+		return http.StatusNoContent
+	})
+	require.Error(t, c.process(context.Background(), path))
+	checkpoint, err := loadCheckpoint(checkpointFile)
+	require.NoError(t, err)
+	require.EqualValues(t, strings.Index(input.String(), "cpu value=4"), checkpoint.Offset)
+	require.Equal(t, "db0", checkpoint.Database)
+	require.Equal(t, "autogen", checkpoint.RetentionPolicy)
+
+	c, writes := newTestImportCommand(t, &ImportConfig{BatchSize: 2, Workers: 1, Resume: true}, nil)
+	require.NoError(t, c.process(context.Background(), path))
+	var written []string
+	for _, write := range writes() {
+		for _, line := range write.lines {
+			written = append(written, write.database+": "+line)
+		}
+	}
+	require.Equal(t, []string{
+		"db0: cpu value=4 4", "db0: cpu value=5 5", "db0: cpu value=6 6", "db0: cpu value=7 7",
+		"db0: cpu value=8 8", "db0: cpu value=9 9", "db1: mem value=1 1",
+	}, written)
+	_, err = os.Stat(checkpointFile)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestImportResume_Workers(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var input strings.Builder
+	input.WriteString("# DML\n# CONTEXT-DATABASE: db0\n")
+	for i := 0; i < 30; i++ {
+		_, _ = fmt.Fprintf(&input, "cpu,host=h%d value=%d %d\n", i%6, i, i)
+	}
+	path := filepath.Join(t.TempDir(), "data.txt")
+	require.NoError(t, os.WriteFile(path, []byte(input.String()), 0644))
+
+	// the writer of h1 fails a batch, the other writers go on past it
+	const failed = "cpu,host=h1 value=7 7"
+	c, _ := newTestImportCommand(t, &ImportConfig{BatchSize: 1, Workers: 3}, func(lines []string) int {
+		if slices.Contains(lines, failed) {
+			return http.StatusBadRequest
+		}
+		return http.StatusNoContent
+	})
+	require.Error(t, c.process(context.Background(), path))
+	failedShard := c.shardOf(lineSeriesKey(failed))
+
+	c, writes := newTestImportCommand(t, &ImportConfig{BatchSize: 1, Workers: 3, Resume: true}, nil)
+	require.NoError(t, c.process(context.Background(), path))
+	var written []string
+	for _, write := range writes() {
+		written = append(written, write.lines...)
+	}
+	// only the lines of the failed writer from the failed batch on are imported again
+	var expected []string
+	for _, line := range strings.Split(input.String(), "\n")[9:] {
+		if line != "" && c.shardOf(lineSeriesKey(line)) == failedShard {
+			expected = append(expected, line)
+		}
+	}
+	require.Equal(t, failed, expected[0])
+	require.Equal(t, expected, written)
+}
+
+func TestImportResume_FileChanged(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "data.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# DML\n# CONTEXT-DATABASE: db0\ncpu value=1 1\n"), 0644))
+	checkpointFile, err := checkpointPath(path)
+	require.NoError(t, err)
+	checkpoint := importCheckpoint{Path: path, Size: 1, ModTime: time.Now(), Offset: 1}
+	require.NoError(t, checkpoint.save(checkpointFile))
+
+	c, writes := newTestImportCommand(t, &ImportConfig{Resume: true}, nil)
+	require.ErrorContains(t, c.process(context.Background(), path), "is changed since its checkpoint was saved")
+	require.Empty(t, writes())
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openGemini/openGemini-cli/core"
+)
+
+const defaultRetryBackoff = time.Second
+
+// errPartialWrite is returned when the column writer wrote only some points of the batch, the
+// whole batch is written again
+var errPartialWrite = errors.New("partial write failure")
+
+// isTransient reports whether the write may succeed when it is tried again, like timeouts,
+// the overloaded or restarting server and the partial writes
+func isTransient(err error) bool {
+	var statusErr *core.HttpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	}
+	return errors.Is(err, errPartialWrite) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryDelay is the backoff before the retry, it doubles from RetryBackoff up to RetryMaxBackoff
+func (icfg *ImportConfig) retryDelay(retry int) time.Duration {
+	delay := icfg.RetryBackoff
+	for i := 1; i < retry && delay < icfg.RetryMaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, icfg.RetryMaxBackoff)
+}
+
+// rejectWriter writes the batches failed after the retries to the --reject-file in the format
+// they were imported from, annotated by the error, so the file can be imported again. Only the
+// line protocol and csv formats are supported.
+type rejectWriter struct {
+	path   string
+	format string
+	// header is the csv header of the file being imported, it is written before its first rejected
+	// rows unless it is the header written last
+	header  []string
+	written []string
+
+	mu   sync.Mutex
+	file *os.File
+	// opened is set once the reject file is created, the next files append to it
+	opened bool
+}
+
+func (r *rejectWriter) write(batch *importBatch, reason error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		flag := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if r.opened {
+			flag = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+		file, err := os.OpenFile(r.path, flag, 0644)
+		if err != nil {
+			return err
+		}
+		r.file, r.opened = file, true
+	}
+	if r.format == importFormatCSV && (r.written == nil || !slices.Equal(r.header, r.written)) {
+		if err := writeCSVRows(r.file, [][]string{r.header}); err != nil {
+			return err
+		}
+		r.written = r.header
+	}
+
+	var buf = bufio.NewWriter(r.file)
+	annotation := "# ERROR: " + strings.Join(strings.Fields(reason.Error()), " ") + "\n"
+	if r.format == importFormatCSV {
+		_, _ = buf.WriteString(annotation)
+		if err := writeCSVRows(buf, batch.rows); err != nil {
+			return err
+		}
+		return buf.Flush()
+	}
+	_, _ = fmt.Fprintf(buf, "%s\n%s %s\n%s %s\n", importTokenDML, importTokenDatabase, batch.database,
+		importTokenRetentionPolicy, batch.retentionPolicy)
+	_, _ = buf.WriteString(annotation)
+	for _, line := range batch.lines {
+		_, _ = buf.WriteString(line + "\n")
+	}
+	return buf.Flush()
+}
+
+func (r *rejectWriter) close() error {
+	if r == nil || r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func writeCSVRows(w io.Writer, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
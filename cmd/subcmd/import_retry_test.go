@@ -0,0 +1,128 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openGemini/openGemini-cli/core"
+)
+
+func TestIsTransient(t *testing.T) {
+	require.True(t, isTransient(&core.HttpStatusError{StatusCode: http.StatusServiceUnavailable}))
+	require.True(t, isTransient(&core.HttpStatusError{StatusCode: http.StatusTooManyRequests}))
+	require.False(t, isTransient(&core.HttpStatusError{StatusCode: http.StatusBadRequest}))
+	require.True(t, isTransient(status.Error(codes.Unavailable, "connection refused")))
+	require.False(t, isTransient(status.Error(codes.InvalidArgument, "bad request")))
+	require.True(t, isTransient(fmt.Errorf("write failed, code: 1, %w", errPartialWrite)))
+	require.True(t, isTransient(fmt.Errorf("post: %w", context.DeadlineExceeded)))
+	require.False(t, isTransient(errors.New("field type conflict")))
+}
+
+func TestRetryDelay(t *testing.T) {
+	cfg := &ImportConfig{RetryBackoff: time.Second, RetryMaxBackoff: 5 * time.Second}
+	require.Equal(t, time.Second, cfg.retryDelay(1))
+	require.Equal(t, 2*time.Second, cfg.retryDelay(2))
+	require.Equal(t, 4*time.Second, cfg.retryDelay(3))
+	require.Equal(t, 5*time.Second, cfg.retryDelay(4))
+}
+
+func TestImportRetryAndReject(t *testing.T) {
+	rejectFile := filepath.Join(t.TempDir(), "rejected.txt")
+	var unavailable = 2
+	c, writes := newTestImportCommand(t, &ImportConfig{
+		BatchSize:       2,
+		Workers:         1,
+		Retries:         3,
+		RetryBackoff:    time.Millisecond,
+		RetryMaxBackoff: time.Millisecond,
+		RejectFile:      rejectFile,
+	}, func(lines []string) int {
+		switch {
+		case strings.Contains(lines[0], "bad"):
+			return http.StatusBadRequest
+		case unavailable > 0:
+			unavailable--
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusNoContent
+	})
+	input := "# DML\n# CONTEXT-DATABASE: db0\ncpu value=1 1\ncpu value=2 2\nbad value=3 3\nbad value=4 4\n"
+	c.startPipeline(context.Background())
+	require.NoError(t, c.parse(context.Background(), strings.NewReader(input)))
+	require.ErrorContains(t, c.stopPipeline(), "2 points in 1 batches rejected")
+
+	// the first batch is written after two retries
+	require.Equal(t, []recordedWrite{{database: "db0", lines: []string{"cpu value=1 1", "cpu value=2 2"}}}, writes())
+	rejected, err := os.ReadFile(rejectFile)
+	require.NoError(t, err)
+	require.Equal(t, "# DML\n# CONTEXT-DATABASE: db0\n# CONTEXT-RETENTION-POLICY: autogen\n"+
+		"# ERROR: write failed: 400 Bad Request\nbad value=3 3\nbad value=4 4\n", string(rejected))
+}
+
+func TestImportRetry_Interrupted(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "data.txt")
+	input := "# DML\n# CONTEXT-DATABASE: db0\ncpu value=1 1\ncpu value=2 2\n"
+	require.NoError(t, os.WriteFile(path, []byte(input), 0644))
+	rejectFile := filepath.Join(t.TempDir(), "rejected.txt")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, _ := newTestImportCommand(t, &ImportConfig{
+		BatchSize:       2,
+		Workers:         1,
+		Retries:         3,
+		RetryBackoff:    time.Hour,
+		RetryMaxBackoff: time.Hour,
+		RejectFile:      rejectFile,
+	}, func(lines []string) int {
+		// interrupt the import while the writer waits to retry
+		cancel()
+		return http.StatusServiceUnavailable
+	})
+	start := time.Now()
+	require.ErrorIs(t, c.process(ctx, path), context.Canceled)
+	require.Less(t, time.Since(start), time.Minute)
+
+	// the batch is neither rejected nor acknowledged, the checkpoint starts from it
+	require.Zero(t, c.pipeline.stats.failedBatches.Load())
+	_, err := os.Stat(rejectFile)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	checkpointFile, err := checkpointPath(path)
+	require.NoError(t, err)
+	checkpoint, err := loadCheckpoint(checkpointFile)
+	require.NoError(t, err)
+	require.EqualValues(t, strings.Index(input, "cpu value=1"), checkpoint.Offset)
+}
+
+func TestImportCommand_RejectFileFormat(t *testing.T) {
+	for _, format := range []string{importFormatJSONInflux, importFormatJSONProm} {
+		err := new(ImportCommand).Run(&ImportConfig{Format: format, RejectFile: "rejected.json"})
+		require.ErrorContains(t, err, "--reject-file only supports --format line_protocol and csv")
+	}
+}
@@ -120,11 +120,8 @@ func (fsm *ImportFileFSM) processJsonP(dec *json.Decoder) (FSMCall, error) {
 				dataSlice = append(dataSlice, fmt.Sprintf("%s %s=%s %s", line, command.cfg.Fields[0], res.Value[1], strconv.FormatFloat(res.Value[0].(float64), 'f', -1, 64)))
 			}
 
-			command.fsm.batchLPBuffer = append(command.fsm.batchLPBuffer, dataSlice...)
-			if len(command.fsm.batchLPBuffer) < command.cfg.BatchSize {
-				return nil
-			}
-			return command.excuteByLPBuffer(ctx)
+			command.bufferLines(dataSlice...)
+			return nil
 
 		}, nil
 	}
@@ -240,11 +237,8 @@ func (fsm *ImportFileFSM) processJsonI(dec *json.Decoder) (FSMCall, error) {
 
 			}
 
-			command.fsm.batchLPBuffer = append(command.fsm.batchLPBuffer, dataSlice...)
-			if len(command.fsm.batchLPBuffer) < command.cfg.BatchSize {
-				return nil
-			}
-			return command.excuteByLPBuffer(ctx)
+			command.bufferLines(dataSlice...)
+			return nil
 		}, nil
 	}
 
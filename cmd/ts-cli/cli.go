@@ -16,8 +16,14 @@ package main
 
 import (
 	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
 
 	"github.com/openGemini/openGemini-cli/cmd/subcmd"
 	"github.com/openGemini/openGemini-cli/common"
@@ -27,6 +33,9 @@ import (
 type Command struct {
 	cmd     *cobra.Command
 	options *core.CommandLineConfig
+
+	configPath string
+	profile    string
 }
 
 func (m *Command) rootCommand() {
@@ -39,10 +48,35 @@ func (m *Command) rootCommand() {
 			DisableDescriptions: true,
 			HiddenDefaultCmd:    true,
 		},
-		Run: func(cmd *cobra.Command, args []string) {
-			core.NewCommandLine(m.options).Run()
+		Example: `
+	$ ts-cli --host localhost --port 8086 --database db0
+
+	$ ts-cli --database db0 --execute "SHOW MEASUREMENTS"
+
+	$ ts-cli --database db0 --file statements.iql --continue-on-error
+
+	$ echo "SHOW DATABASES" | ts-cli
+
+	$ ts-cli --profile prod`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := m.applyConfig(cmd); err != nil {
+				cmd.SilenceUsage = true
+				cmd.SilenceErrors = true
+				return err
+			}
+			return requirePassword(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// flags are valid here, the remaining errors come from the executed statements
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			m.options.Profile = m.activeProfile()
+			return core.NewCommandLine(m.options).Run()
 		},
 	}
+	defaultConfigPath, _ := subcmd.DefaultConfigPath()
+	m.cmd.PersistentFlags().StringVarP(&m.configPath, "config", "", defaultConfigPath, "config file of the connection settings and profiles.")
+	m.cmd.PersistentFlags().StringVarP(&m.profile, "profile", "", "", "profile of the config file to connect with, default only use the [default] section, also set by "+subcmd.ConfigProfileEnv+".")
 	m.cmd.Flags().StringVarP(&m.options.Host, "host", "H", common.DefaultHost, "ts-sql host to connect to.")
 	m.cmd.Flags().IntVarP(&m.options.Port, "port", "p", common.DefaultHttpPort, "ts-sql tcp port to connect to.")
 	m.cmd.Flags().StringVarP(&m.options.UnixSocket, "socket", "S", "", "openGemini unix domain socket to connect to. ")
@@ -56,10 +90,21 @@ func (m *Command) rootCommand() {
 	m.cmd.Flags().StringVarP(&m.options.CertKey, "cert-key", "k", "", "client certificate password.")
 	m.cmd.Flags().BoolVarP(&m.options.InsecureHostname, "insecure-hostname", "I", false, "ignore server certificate hostname verification when connecting openGemini by https.")
 	m.cmd.Flags().StringVarP(&m.options.Database, "database", "d", "", "database to connect to openGemini.")
+	m.cmd.Flags().StringVarP(&m.options.Precision, "precision", "", "", "format of the timestamp: rfc3339, h, m, s, ms, u or ns, default ns.")
 	m.cmd.Flags().BoolVarP(&m.options.DisplayVertical, "vertical", "V", false, "print query output rows vertically(one line per column value), like key-value style, default horizontal(table style) mode.")
+	m.cmd.Flags().StringVarP(&m.options.OutputFormat, "format", "", "table", "output format of query results, support 'table', 'column', 'csv', 'tsv', 'markdown', 'json', 'pretty-json'.")
+	m.cmd.Flags().StringVarP(&m.options.Execute, "execute", "e", "", "execute the statements and quit, statements end with ';' and may span multiple lines.")
+	m.cmd.Flags().StringVarP(&m.options.ScriptFile, "file", "f", "", "execute the statements in the script file and quit, statements end with ';' and may span multiple lines.")
+	m.cmd.Flags().IntVarP(&m.options.HistorySize, "history-size", "", common.DefaultHistorySize, "number of statements kept in the history file ~/.ts-cli/history, 0 disables the history.")
+	m.cmd.Flags().StringVarP(&m.options.Pager, "pager", "", "off", "page the output longer than the terminal in the interactive shell, 'on' uses $PAGER or 'less -S', 'off' or the pager command.")
+	m.cmd.Flags().StringVarP(&m.options.PromptTemplate, "prompt-template", "", core.DefaultPromptTemplate, "prompt of the interactive shell, the placeholders {user}, {host}, {port}, {db}, {rp} and {profile} are replaced by the connection.")
+	m.cmd.Flags().BoolVarP(&m.options.Production, "production", "", false, "mark the connection as production, the prompt of the interactive shell is shown in red.")
+	m.cmd.Flags().StringVarP(&m.options.Theme, "theme", "", "", "colors of the syntax highlighting in the interactive shell, 'dark', 'light' or 'off', default dark unless NO_COLOR is set.")
+	m.cmd.Flags().DurationVarP(&m.options.Wait, "wait", "", 0, "wait up to the duration like 30s for the server to be ready at startup, retrying with backoff, default fail at once.")
+	m.cmd.Flags().BoolVarP(&m.options.ContinueOnError, "continue-on-error", "", false, "keep executing the remaining statements after a statement failed, default stop at the first error.")
 
-	m.cmd.MarkFlagsRequiredTogether("username", "password")
 	m.cmd.MarkFlagsRequiredTogether("cert", "cert-key")
+	m.cmd.MarkFlagsMutuallyExclusive("execute", "file")
 }
 
 func (m *Command) versionCommand() {
@@ -109,7 +154,14 @@ func (m *Command) importCommand() {
 	cmd.Flags().BoolVarP(&config.ColumnWrite, "column-write", "w", false, "use high performance column writing protocol, default use line protocol.")
 	cmd.Flags().IntVarP(&config.ColumnWritePort, "column-write-port", "W", common.DefaultColumnWritePort, "high performance column writing protocol service port.")
 	cmd.Flags().IntVarP(&config.BatchSize, "batch-size", "b", common.DefaultBatchSize, "enable batch submission to improve write performance.")
-	cmd.Flags().StringVarP(&config.Path, "path", "T", "", "import file path to store openGemini.")
+	cmd.Flags().IntVarP(&config.Workers, "workers", "", common.DefaultImportWorkers, "number of batches written concurrently, the points of a series are always written in order.")
+	cmd.Flags().IntVarP(&config.Retries, "retries", "", common.DefaultImportRetries, "number of times a batch failed by a timeout, 5xx, 429 or partial write is written again.")
+	cmd.Flags().DurationVarP(&config.RetryBackoff, "retry-backoff", "", time.Second, "delay before the first retry of a batch, it doubles on every retry.")
+	cmd.Flags().DurationVarP(&config.RetryMaxBackoff, "retry-max-backoff", "", 30*time.Second, "longest delay between the retries of a batch.")
+	cmd.Flags().StringVarP(&config.RejectFile, "reject-file", "", "", "file collecting the batches failed after the retries in the imported format, annotated by the error, only for line_protocol and csv.")
+	cmd.Flags().BoolVarP(&config.Resume, "resume", "", false, "continue the line protocol file from the checkpoint saved by the interrupted import, the lines already written are skipped unless --workers changed.")
+	cmd.Flags().IntVarP(&config.MaxInflightBatches, "max-inflight-batches", "", 0, "number of batches read but not written yet, it bounds the memory, default twice the workers.")
+	cmd.Flags().StringArrayVarP(&config.Paths, "path", "T", nil, "import file, glob pattern or directory, - is stdin, repeat it to import several.")
 	cmd.Flags().StringVarP(&config.Format, "format", "f", common.DefaultFormat, "import file format, support 'line_protocol', 'csv', 'jsoni', 'jsonp'.")
 	cmd.Flags().StringSliceVarP(&config.Tags, "tags", "", nil, "measurement tags name.")
 	cmd.Flags().StringSliceVarP(&config.Fields, "fields", "", nil, "measurement fields name, if not specified, the remaining columns will act as fields.")
@@ -119,7 +171,6 @@ func (m *Command) importCommand() {
 	cmd.Flags().StringVarP(&config.RetentionPolicy, "retention-policy", "r", common.DefaultRetentionPolicy, "measurement retention policy.")
 	cmd.Flags().StringVarP(&config.Precision, "precision", "U", "ns", "precision for time unit conversion, support 's', 'ms', 'us', 'ns'.")
 
-	cmd.MarkFlagsRequiredTogether("username", "password")
 	cmd.MarkFlagsRequiredTogether("cert", "cert-key")
 	m.cmd.AddCommand(cmd)
 }
@@ -168,11 +219,149 @@ func (m *Command) exportCommand() {
 	m.cmd.AddCommand(cmd)
 }
 
+func (m *Command) configCommand() {
+	var config = new(subcmd.ConfigCommand)
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "manage the config file of the connection settings and profiles",
+		Long: `Manage the config file of the connection settings and profiles, ~/.ts-cli/config.toml by default.
+The [default] section applies to every command, the [profiles.<name>] section selected by --profile overrides it.
+Environment variables like OPENGEMINI_HOST or OPENGEMINI_PASSWORD override the config file, explicit flags override all.`,
+		Example: `
+	$ ts-cli config set host prod.example.com --profile prod
+
+	$ ts-cli config show --profile prod
+
+	$ ts-cli config list`,
+		CompletionOptions: cobra.CompletionOptions{
+			DisableNoDescFlag:   true,
+			DisableDescriptions: true,
+			HiddenDefaultCmd:    true,
+		},
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			config.Path = m.configPath
+			config.Profile = m.profile
+		},
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "list the profiles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return config.List(os.Stdout)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show the settings of the profile, the password is masked",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return config.Show(os.Stdout)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "set the value of the key in the profile, or in the [default] section without --profile",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return config.Set(args[0], args[1])
+		},
+	})
+	m.cmd.AddCommand(cmd)
+}
+
+// applyConfig sets the flags which are not given explicitly from the environment variables and the
+// profile of the config file
+func (m *Command) applyConfig(cmd *cobra.Command) error {
+	if cmd.Name() == "version" || (cmd.HasParent() && cmd.Parent().Name() == "config") {
+		return nil
+	}
+	file, err := subcmd.LoadConfigFile(m.configPath)
+	if err != nil {
+		return err
+	}
+	values, err := file.Values(m.activeProfile(), os.Getenv)
+	if err != nil {
+		return err
+	}
+	if host, ok := values["host"]; ok && cmd.Name() == "export" {
+		// the remote export connects to host:port of the profile
+		port, ok := values["port"]
+		if !ok {
+			port = strconv.Itoa(common.DefaultHttpPort)
+		}
+		values["remote"] = net.JoinHostPort(host, port)
+	}
+	for name, value := range values {
+		flag := configFlag(cmd, name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		if err = cmd.Flags().Set(flag.Name, value); err != nil {
+			return fmt.Errorf("invalid %s %q in the config: %w", name, value, err)
+		}
+	}
+	return nil
+}
+
+// requirePassword asks for the password of the username on a terminal once the flags are set from the
+// config, the password may come from the flag, the profile or the environment variable
+func requirePassword(cmd *cobra.Command) error {
+	username, password := cmd.Flags().Lookup("username"), cmd.Flags().Lookup("password")
+	if username == nil || password == nil || username.Value.String() == "" || password.Value.String() != "" {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return fmt.Errorf("missing the password of user %s, set it by --password, the config file or %s",
+			username.Value.String(), subcmd.ConfigKey{Name: "password"}.EnvName())
+	}
+	fmt.Printf("password: ")
+	typed, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Printf("\n")
+	if err != nil {
+		return err
+	}
+	return cmd.Flags().Set("password", string(typed))
+}
+
+// activeProfile returns the profile selected by --profile or the environment variable
+func (m *Command) activeProfile() string {
+	if m.profile != "" {
+		return m.profile
+	}
+	return os.Getenv(subcmd.ConfigProfileEnv)
+}
+
+// configFlag returns the flag of the command set by the config key, or nil if the command has no such setting
+func configFlag(cmd *cobra.Command, name string) *pflag.Flag {
+	switch cmd.Name() {
+	case "import":
+		// the precision of import is the time unit of the imported timestamps
+		if name == "precision" {
+			return nil
+		}
+	case "export":
+		switch name {
+		case "username", "password", "ssl":
+			name = "remote" + name
+		case "remote":
+		default:
+			return nil
+		}
+	}
+	return cmd.Flags().Lookup(name)
+}
+
 func (m *Command) load() {
 	m.rootCommand()
 	m.versionCommand()
 	m.importCommand()
 	m.exportCommand()
+	m.configCommand()
 }
 
 func (m *Command) Execute() error {
@@ -184,5 +373,6 @@ func main() {
 	command.load()
 	if err := command.Execute(); err != nil {
 		fmt.Printf("execute command failed: %s\n", err)
+		os.Exit(1)
 	}
 }
@@ -23,6 +23,9 @@ const (
 	DefaultGrpcPort        = 8305
 	DefaultRequestTimeout  = 5000
 	DefaultBatchSize       = 100
+	DefaultImportWorkers   = 4
+	DefaultImportRetries   = 3
+	DefaultHistorySize     = 1000
 )
 
 const ColumnNameTime = "time"
@@ -0,0 +1,114 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+// errQueryCanceled is returned when the running query is canceled by Ctrl-C
+var errQueryCanceled = errors.New("query canceled")
+
+// cancelQuery is called once the query is canceled by Ctrl-C. Only the request is dropped by
+// default and the server finishes the query on its own, with kill_on_cancel the query is looked
+// up in SHOW QUERIES and killed on the server as well.
+func (cl *CommandLine) cancelQuery(command string) error {
+	if !cl.killOnCancel {
+		return errQueryCanceled
+	}
+	qid, err := cl.killQuery(command)
+	if err != nil {
+		return fmt.Errorf("%w, kill it on the server: %s", errQueryCanceled, err)
+	}
+	fmt.Printf("Killed query %s on the server\n", qid)
+	return errQueryCanceled
+}
+
+func (cl *CommandLine) killQuery(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cl.Timeout)*time.Millisecond)
+	defer cancel()
+	response, err := cl.httpClient.Query(ctx, &opengemini.Query{Command: "SHOW QUERIES"})
+	if err != nil {
+		return "", err
+	}
+	if response.Error != "" {
+		return "", errors.New(response.Error)
+	}
+	qid, ok := findQueryID(response, command)
+	if !ok {
+		return "", errors.New("the query is not listed by SHOW QUERIES")
+	}
+	response, err = cl.httpClient.Query(ctx, &opengemini.Query{Command: "KILL QUERY " + qid})
+	if err != nil {
+		return "", err
+	}
+	if response.Error != "" {
+		return "", errors.New(response.Error)
+	}
+	return qid, nil
+}
+
+// findQueryID returns the qid of the query running the command in the SHOW QUERIES result,
+// the latest one is chosen when the same command is running several times
+func findQueryID(response *opengemini.QueryResult, command string) (string, bool) {
+	command = normalizeQuery(command)
+	var found bool
+	var latest int64
+	for _, result := range response.Results {
+		for _, series := range result.Series {
+			qidIndex := slices.Index(series.Columns, "qid")
+			queryIndex := slices.Index(series.Columns, "query")
+			if qidIndex < 0 || queryIndex < 0 {
+				continue
+			}
+			for _, value := range series.Values {
+				if max(qidIndex, queryIndex) >= len(value) || normalizeQuery(fmt.Sprint(value[queryIndex])) != command {
+					continue
+				}
+				qid, err := strconv.ParseInt(formatQueryID(value[qidIndex]), 10, 64)
+				if err != nil {
+					continue
+				}
+				if !found || qid > latest {
+					found, latest = true, qid
+				}
+			}
+		}
+	}
+	return strconv.FormatInt(latest, 10), found
+}
+
+// formatQueryID renders the qid column, the client decodes the numbers as json.Number
+func formatQueryID(value any) string {
+	if number, ok := value.(json.Number); ok {
+		return number.String()
+	}
+	return fmt.Sprint(value)
+}
+
+// normalizeQuery collapses the whitespace and drops the trailing `;` to compare the command with
+// the text listed by SHOW QUERIES
+func normalizeQuery(command string) string {
+	return strings.TrimSuffix(strings.Join(strings.Fields(command), " "), ";")
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindQueryID(t *testing.T) {
+	// decoded like the http client does, the ids are json.Number
+	var response = new(opengemini.QueryResult)
+	decoder := json.NewDecoder(strings.NewReader(`{"results":[{"statement_id":0,"series":[{` +
+		`"columns":["qid","query","database","duration"],"values":[` +
+		`[7,"SELECT * FROM cpu","db0","10s"],` +
+		`[9007199254740993,"SELECT  *\nFROM cpu","db0","1s"],` +
+		`[9,"SHOW QUERIES","","0s"]]}]}]}`))
+	decoder.UseNumber()
+	require.NoError(t, decoder.Decode(response))
+	qid, ok := findQueryID(response, "SELECT * FROM cpu;")
+	require.True(t, ok)
+	require.Equal(t, "9007199254740993", qid)
+
+	_, ok = findQueryID(response, "SELECT * FROM mem")
+	require.False(t, ok)
+}
+
+func TestCommandLine_CancelQuery(t *testing.T) {
+	var commands []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		commands = append(commands, r.Form.Get("q"))
+		if r.Form.Get("q") == "SHOW QUERIES" {
+			_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"columns":["qid","query","database","duration"],"values":[[36,"SELECT * FROM cpu","db0","12s"]]}]}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"statement_id":0}]}`))
+	}))
+	defer server.Close()
+	cl := &CommandLine{CommandLineConfig: &CommandLineConfig{Timeout: 1000}, httpClient: newTestHttpClient(server)}
+
+	require.ErrorIs(t, cl.cancelQuery("SELECT * FROM cpu"), errQueryCanceled)
+	require.Empty(t, commands)
+
+	cl.killOnCancel = true
+	require.ErrorIs(t, cl.cancelQuery("SELECT * FROM cpu"), errQueryCanceled)
+	require.Equal(t, []string{"SHOW QUERIES", "KILL QUERY 36"}, commands)
+
+	err := cl.cancelQuery("SELECT * FROM mem")
+	require.ErrorIs(t, err, errQueryCanceled)
+	require.ErrorContains(t, err, "not listed by SHOW QUERIES")
+}
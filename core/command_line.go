@@ -15,13 +15,17 @@
 package core
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"os/signal"
 	"runtime/debug"
-	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -36,16 +40,47 @@ import (
 	"github.com/openGemini/openGemini-cli/prompt"
 )
 
+// maxStatementSize is the longest single statement accepted from a script or stdin
+const maxStatementSize = 64 * 1024 * 1024
+
 type CommandLine struct {
 	*CommandLineConfig
 	httpClient HttpClient
 	parser     geminiql.QLParser
 	prompt     *prompt.Prompt
+	history    *prompt.History
+	metadata   *metadataCache
+	// pending is the incomplete statement entered at the prompt, it is waiting for a `;`
+	pending string
 
 	executeAt time.Time
 	timer     bool
 	debug     bool
 	suggest   bool
+	chunked   bool
+	chunkSize int
+	// variables are set by `\set` and interpolated into the statements sent to the server
+	variables map[string]string
+	// queries are saved by `\save` and run by `\run`, loaded on the first use
+	queries *prompt.SavedQueries
+	// lastStatement is saved by `\save` without a statement
+	lastStatement string
+	// captured collects the rendered results instead of the terminal while watch runs
+	captured io.Writer
+	// redirect is the file the query output goes to, nil writes to the terminal only
+	redirect *redirect
+	// pager is the command paging the output longer than the terminal, empty disables the pager
+	pager string
+	// killOnCancel kills the query on the server as well when it is canceled by Ctrl-C
+	killOnCancel bool
+	// validate parses the statements on the client before sending them to the server
+	validate bool
+	// promptTemplate is rendered as the prefix of the prompt, see promptPrefix
+	promptTemplate string
+	// theme is the colors of the syntax highlighting at the prompt
+	theme       string
+	format      OutputFormat
+	valueFormat valueFormat
 }
 
 func NewCommandLine(cfg *CommandLineConfig) *CommandLine {
@@ -54,50 +89,137 @@ func NewCommandLine(cfg *CommandLineConfig) *CommandLine {
 		slog.Error("create http client failed", "reason", err)
 		os.Exit(1)
 	}
+	format, err := ParseOutputFormat(cfg.OutputFormat)
+	if err != nil {
+		slog.Error("invalid output format", "reason", err)
+		os.Exit(1)
+	}
 	var cl = &CommandLine{
 		CommandLineConfig: cfg,
 		parser:            geminiql.QLNewParser(),
 		httpClient:        httpClient,
+		format:            format,
+		valueFormat:       valueFormat{floatPrecision: -1},
+		pager:             pagerCommand(cfg.Pager),
+		promptTemplate:    cfg.PromptTemplate,
+	}
+	if cl.promptTemplate == "" {
+		cl.promptTemplate = DefaultPromptTemplate
+	}
+	precision, _ := lookupSetting("precision")
+	if err = precision.set(cl, cfg.Precision); err != nil {
+		slog.Error("invalid precision", "reason", err)
+		os.Exit(1)
+	}
+	cl.theme = prompt.DefaultTheme()
+	if cfg.Theme != "" {
+		theme, _ := lookupSetting("theme")
+		if err = theme.set(cl, cfg.Theme); err != nil {
+			slog.Error("invalid theme", "reason", err)
+			os.Exit(1)
+		}
 	}
-	cl.prompt = prompt.NewPrompt(cl.executor)
 	return cl
 }
 
+// errQuit is returned by execute when the input asks to leave the program
+var errQuit = errors.New("quit")
+
 func (cl *CommandLine) executor(input string) {
+	defer func() { cl.prompt.SetContinued(cl.pending != "") }()
+	for _, statement := range cl.readStatements(input) {
+		err := cl.execute(statement)
+		if errors.Is(err, errQuit) {
+			cl.prompt.Destruction(nil)
+		}
+		// only the statements entered at the prompt are recorded, the username and password
+		// typed after `auth` are read from the terminal directly and never reach here
+		entry := statement
+		if !cl.isShellCommand(statement) {
+			entry += ";"
+		}
+		if herr := cl.history.Add(entry); herr != nil {
+			fmt.Printf("error: save history: %s\n", herr)
+		}
+		if err != nil {
+			printError(os.Stdout, "error: ", err)
+			return
+		}
+	}
+}
+
+// readStatements appends the line to the pending input and returns the statements completed
+// by a `;`, the incomplete statement is kept pending for the next line. Shell commands like
+// `use db0` or `timer` don't need a terminator and are complete at the end of the line.
+func (cl *CommandLine) readStatements(line string) []string {
+	text := line
+	if cl.pending != "" {
+		text = cl.pending + "\n" + line
+	}
+	statements, rest := geminiql.SplitStatements(text)
+	cl.pending = ""
+	switch {
+	case strings.TrimSpace(rest) == "":
+	case !strings.Contains(rest, "\n") && cl.isShellCommand(rest):
+		statements = append(statements, strings.TrimSpace(rest))
+	default:
+		cl.pending = strings.TrimLeft(rest, " \t")
+	}
+	return statements
+}
+
+// isShellCommand reports whether the input is executed by the shell instead of the server
+func (cl *CommandLine) isShellCommand(input string) bool {
+	input = strings.TrimSpace(input)
+	if input == "quit" || input == "exit" || input == "\\q" {
+		return true
+	}
+	ast := &geminiql.QLAst{}
+	lexer := geminiql.QLNewLexer(geminiql.NewTokenizer(strings.NewReader(input)), ast)
+	cl.parser.Parse(lexer)
+	return ast.Error == nil && ast.Stmt != nil
+}
+
+func (cl *CommandLine) execute(input string) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Println("panic recovered", r)
 			fmt.Println("stack trace:")
 			debug.PrintStack()
+			err = fmt.Errorf("panic recovered: %v", r)
 		}
 	}()
 	// no input nothing to do
 	if input == "" {
-		return
+		return nil
 	}
 
 	// input token to exit program
 	if input == "quit" || input == "exit" || input == "\\q" {
-		cl.prompt.Destruction(nil)
+		return errQuit
 	}
 
-	ast := &geminiql.QLAst{}
-	lexer := geminiql.QLNewLexer(geminiql.NewTokenizer(strings.NewReader(input)), ast)
-	cl.parser.Parse(lexer)
+	// backslash commands are never saved by `\save` without a statement
+	if !strings.HasPrefix(input, `\`) {
+		cl.lastStatement = input
+	}
 
 	cl.executeAt = time.Now()
 	defer cl.elapse()
+	return cl.executeStatement(input)
+}
+
+// executeStatement executes the shell commands locally and sends the other statements to the server
+func (cl *CommandLine) executeStatement(input string) error {
+	ast := &geminiql.QLAst{}
+	lexer := geminiql.QLNewLexer(geminiql.NewTokenizer(strings.NewReader(input)), ast)
+	cl.parser.Parse(lexer)
 
-	var err error
 	// parse token success
 	if ast.Error == nil {
-		err = cl.executeOnLocal(ast.Stmt)
-	} else {
-		err = cl.executeOnRemote(input)
-	}
-	if err != nil {
-		fmt.Printf("error: %s\n", err)
+		return cl.executeOnLocal(ast.Stmt)
 	}
+	return cl.executeOnRemote(input)
 }
 
 func (cl *CommandLine) elapse() {
@@ -112,6 +234,26 @@ func (cl *CommandLine) executeOnLocal(stmt geminiql.Statement) error {
 	switch stmt := stmt.(type) {
 	case *geminiql.UseStatement:
 		return cl.executeUse(stmt)
+	case *geminiql.SetVariableStatement:
+		return cl.executeSetVariable(stmt)
+	case *geminiql.UnsetVariableStatement:
+		return cl.executeUnsetVariable(stmt)
+	case *geminiql.SaveQueryStatement:
+		return cl.executeSaveQuery(stmt)
+	case *geminiql.RunQueryStatement:
+		return cl.executeRunQuery(stmt)
+	case *geminiql.ListQueriesStatement:
+		return cl.executeListQueries(stmt)
+	case *geminiql.WatchStatement:
+		return cl.executeWatch(stmt)
+	case *geminiql.ConnectStatement:
+		return cl.executeConnect(stmt)
+	case *geminiql.StatusStatement:
+		return cl.executeStatus(stmt)
+	case *geminiql.OutputStatement:
+		return cl.executeOutput(stmt)
+	case *geminiql.PagerStatement:
+		return cl.executePager(stmt)
 	case *geminiql.PrecisionStatement:
 		return cl.executePrecision(stmt)
 	case *geminiql.HelpStatement:
@@ -127,25 +269,74 @@ func (cl *CommandLine) executeOnLocal(stmt geminiql.Statement) error {
 	case *geminiql.InsertStatement:
 		return cl.executeInsert(stmt)
 	case *geminiql.ChunkedStatement:
-		return errors.New("not implemented")
+		return cl.executeChunked(stmt)
 	case *geminiql.ChunkSizeStatement:
-		return errors.New("not implemented")
+		return cl.executeChunkSize(stmt)
 	case *geminiql.VerticalStatement:
 		return cl.executeVertical(stmt)
+	case *geminiql.FormatStatement:
+		return cl.executeFormat(stmt)
+	case *geminiql.SetStatement:
+		return cl.executeSet(stmt)
+	case *geminiql.ShowSettingsStatement:
+		return cl.executeShowSettings(stmt)
+	case *geminiql.HistoryStatement:
+		return cl.executeHistory(stmt)
 	default:
 		return fmt.Errorf("unsupport stmt %s", stmt)
 	}
 }
 
 func (cl *CommandLine) executeOnRemote(s string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cl.Timeout)*time.Millisecond)
+	if changesSchema(s) {
+		defer cl.metadata.invalidate()
+	}
+	// a chunked query streams for as long as it runs, its timeout only waits for the server to answer
+	ctx, cancel := context.WithCancel(context.Background())
+	if !cl.chunked {
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cl.Timeout)*time.Millisecond)
+	}
 	defer cancel()
-	response, err := cl.httpClient.Query(ctx, &opengemini.Query{
+	s, params := interpolate(s, cl.variables)
+	if cl.validate {
+		if err := validateStatement(s, params); err != nil {
+			return err
+		}
+	}
+	query := &opengemini.Query{
 		Database:        cl.Database,
 		Precision:       opengemini.ToPrecision(cl.Precision),
 		RetentionPolicy: cl.RetentionPolicy,
 		Command:         s,
-	})
+		Params:          params,
+	}
+	if cl.prompt == nil {
+		return withPosition(cl.query(ctx, query), s)
+	}
+	// Ctrl-C cancels the query and returns to the prompt instead of quitting the shell
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	err := cl.query(ctx, query)
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		return cl.cancelQuery(s)
+	}
+	return withPosition(err, s)
+}
+
+func (cl *CommandLine) query(ctx context.Context, query *opengemini.Query) error {
+	if cl.chunked {
+		return cl.httpClient.QueryChunked(ctx, query, cl.chunkSize, func(response *opengemini.QueryResult) error {
+			if response.Error != "" {
+				return errors.New(response.Error)
+			}
+			// the chunks are streamed as they arrive, so they are never paged
+			for _, result := range response.Results {
+				cl.render(cl.outputWriter(), result)
+			}
+			return nil
+		})
+	}
+	response, err := cl.httpClient.Query(ctx, query)
 	if err != nil {
 		return err
 	}
@@ -159,42 +350,82 @@ func (cl *CommandLine) executeOnRemote(s string) error {
 	return nil
 }
 
+// output writes the result to the terminal through the pager if it doesn't fit, or to the file
+// redirected by `\o` and `\tee`
 func (cl *CommandLine) output(result *opengemini.SeriesResult) {
+	if !cl.pageable() {
+		cl.render(cl.outputWriter(), result)
+		return
+	}
+	var buf bytes.Buffer
+	cl.render(&buf, result)
+	if cl.redirect != nil {
+		if _, err := cl.redirect.file.Write(buf.Bytes()); err != nil {
+			fmt.Printf("error: write output: %s\n", err)
+		}
+	}
+	cl.page(buf.Bytes())
+}
+
+func (cl *CommandLine) render(w io.Writer, result *opengemini.SeriesResult) {
+	var err error
+	switch cl.format {
+	case OutputFormatJSON, OutputFormatPrettyJSON:
+		err = writeJSON(w, result, cl.format == OutputFormatPrettyJSON)
+	case OutputFormatCSV:
+		err = writeSeparated(w, result, ',', cl.valueFormat)
+	case OutputFormatTSV:
+		err = writeSeparated(w, result, '\t', cl.valueFormat)
+	default:
+		err = cl.outputSeries(w, result)
+	}
+	if err != nil {
+		fmt.Printf("error: render result failed: %s\n", err)
+	}
+}
+
+func (cl *CommandLine) outputSeries(w io.Writer, result *opengemini.SeriesResult) error {
 	for _, series := range result.Series {
 		if len(series.Columns) == 0 {
 			continue
 		}
 		columnName := series.Columns[0]
 		if columnName == "EXPLAIN ANALYZE" {
-			cl.outputExplainAnalyze(series)
+			cl.outputExplainAnalyze(w, series)
 			continue
 		}
 
-		var tags []string
-		for k, v := range series.Tags {
-			tags = append(tags, fmt.Sprintf("%s=%s", k, v))
-			sort.Strings(tags)
-		}
-
+		tags := seriesTags(series)
 		if series.Name != "" {
-			_, _ = fmt.Fprintf(os.Stdout, "name: %s\n", series.Name)
+			_, _ = fmt.Fprintf(w, "name: %s\n", series.Name)
 		}
 		if len(tags) != 0 {
-			_, _ = fmt.Fprintf(os.Stdout, "tags: %s\n", strings.Join(tags, ", "))
+			_, _ = fmt.Fprintf(w, "tags: %s\n", strings.Join(tags, ", "))
 		}
 
-		if cl.DisplayVertical {
-			cl.prettyVertical(series)
-		} else {
-			cl.prettyTable(series)
+		var err error
+		switch {
+		case cl.format == OutputFormatMarkdown:
+			_, _ = fmt.Fprintln(w)
+			err = writeMarkdown(w, series, cl.valueFormat)
+		case cl.DisplayVertical:
+			cl.prettyVertical(w, series)
+		case cl.format == OutputFormatColumn:
+			err = writeColumn(w, series, cl.valueFormat)
+		default:
+			cl.prettyTable(w, series)
+		}
+		if err != nil {
+			return err
 		}
 
-		_, _ = fmt.Fprintf(os.Stdout, "%d columns, %d rows in set\n", len(series.Columns), len(series.Values))
+		_, _ = fmt.Fprintf(w, "%d columns, %d rows in set\n", len(series.Columns), len(series.Values))
 	}
+	return nil
 }
 
-func (cl *CommandLine) prettyTable(series *opengemini.Series) {
-	table := tablewriter.NewTable(os.Stdout,
+func (cl *CommandLine) prettyTable(w io.Writer, series *opengemini.Series) {
+	table := tablewriter.NewTable(w,
 		tablewriter.WithRenderer(
 			renderer.NewBlueprint(tw.Rendition{Symbols: tw.NewSymbols(tw.StyleASCII)})),
 		tablewriter.WithEastAsian(false),
@@ -203,51 +434,27 @@ func (cl *CommandLine) prettyTable(series *opengemini.Series) {
 	for _, value := range series.Values {
 		tuple := make([]string, len(value))
 		for i, val := range value {
-			switch cv := val.(type) {
-			case int64:
-				tuple[i] = fmt.Sprintf("%d", cv)
-			case float32, float64:
-				tuple[i] = fmt.Sprintf("%.0f", cv)
-			case string:
-				tuple[i] = cv
-			case bool:
-				tuple[i] = fmt.Sprintf("%t", cv)
-			default:
-				tuple[i] = fmt.Sprintf("%v", cv)
-			}
+			tuple[i] = cl.valueFormat.format(val)
 		}
 		_ = table.Append(tuple)
 	}
 	_ = table.Render()
 }
 
-func (cl *CommandLine) prettyVertical(series *opengemini.Series) {
+func (cl *CommandLine) prettyVertical(w io.Writer, series *opengemini.Series) {
 	maxWidth := maxColumnNameWidth(series.Columns) + 1
 	delimiter := strings.Repeat("*", maxWidth)
 	for rowIdx, rowValues := range series.Values {
 		var rowBuffer strings.Builder
 		rowBuffer.WriteString(fmt.Sprintf("%s %d row %s\n", delimiter, rowIdx+1, delimiter)) // write header
 		for columnIdx, columnValue := range rowValues {
-			var vs string
-			switch cv := columnValue.(type) {
-			case int64:
-				vs = fmt.Sprintf("%d", cv)
-			case float32, float64:
-				vs = fmt.Sprintf("%.0f", cv)
-			case string:
-				vs = cv
-			case bool:
-				vs = fmt.Sprintf("%t", cv)
-			default:
-				vs = fmt.Sprintf("%v", cv)
-			}
-			rowBuffer.WriteString(fmt.Sprintf("%*s : %v\n", maxWidth, series.Columns[columnIdx], vs))
+			rowBuffer.WriteString(fmt.Sprintf("%*s : %v\n", maxWidth, series.Columns[columnIdx], cl.valueFormat.format(columnValue)))
 		}
-		fmt.Println(rowBuffer.String())
+		_, _ = fmt.Fprintln(w, rowBuffer.String())
 	}
 }
 
-func (cl *CommandLine) outputExplainAnalyze(result *opengemini.Series) {
+func (cl *CommandLine) outputExplainAnalyze(w io.Writer, result *opengemini.Series) {
 	var buff []string
 	for _, value := range result.Values {
 		for _, content := range value {
@@ -258,11 +465,117 @@ func (cl *CommandLine) outputExplainAnalyze(result *opengemini.Series) {
 			buff = append(buff, s)
 		}
 	}
-	_, _ = fmt.Fprintf(os.Stdout, "EXPLAIN ANALYZE\n---------------\n%s\n", strings.Join(buff, "\n"))
+	_, _ = fmt.Fprintf(w, "EXPLAIN ANALYZE\n---------------\n%s\n", strings.Join(buff, "\n"))
+}
+
+// Run starts the interactive shell, unless statements are given by --execute, --file
+// or a non-terminal stdin, in which case they are executed one by one and Run returns.
+// The server is pinged first, and waited for up to --wait.
+func (cl *CommandLine) Run() error {
+	interactive := cl.Execute == "" && cl.ScriptFile == "" && term.IsTerminal(int(os.Stdin.Fd()))
+	version, err := cl.checkServer(interactive)
+	if err != nil {
+		return err
+	}
+	switch {
+	case cl.Execute != "":
+		return cl.RunStatements(strings.NewReader(cl.Execute))
+	case cl.ScriptFile != "":
+		file, err := os.Open(cl.ScriptFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		return cl.RunStatements(file)
+	case !term.IsTerminal(int(os.Stdin.Fd())):
+		return cl.RunStatements(os.Stdin)
+	}
+	path, err := prompt.DefaultHistoryPath()
+	if err != nil {
+		return err
+	}
+	cl.history = prompt.NewHistory(path, cl.HistorySize)
+	if err = cl.history.Load(); err != nil {
+		fmt.Printf("error: load history: %s\n", err)
+	}
+	cl.metadata = newMetadataCache(cl.httpClient, func() string { return cl.Database }, metadataTTL, time.Duration(cl.Timeout)*time.Millisecond)
+	queries, err := cl.savedQueries()
+	if err != nil {
+		fmt.Printf("error: load saved queries: %s\n", err)
+	}
+	cl.prompt = prompt.NewPrompt(cl.executor, cl.history, cl.metadata, queries)
+	_ = cl.prompt.SetTheme(cl.theme)
+	cl.prompt.SetPrefix(cl.promptPrefix)
+	cl.prompt.SetInterruptHandler(func() { cl.pending = "" })
+	cl.prompt.Run(version)
+	return nil
 }
 
-func (cl *CommandLine) Run() {
-	cl.prompt.Run()
+// RunStatements executes the statements read from reader like the interactive shell does, a
+// statement ends at a `;` and may span several lines, and several statements on one line are
+// separated by `;`. The text left without a `;` at the end of the input is the last statement.
+// Blank lines and lines beginning with "--" are skipped. It stops at the first failed statement
+// unless ContinueOnError is set, and returns an error if any statement failed.
+func (cl *CommandLine) RunStatements(reader io.Reader) error {
+	var failed int
+	// run executes the statement beginning at the line, it reports whether to go on
+	run := func(statement string, line int) (bool, error) {
+		err := cl.execute(statement)
+		if errors.Is(err, errQuit) {
+			return false, nil
+		}
+		if err != nil {
+			failed++
+			printError(os.Stderr, fmt.Sprintf("error: line %d: ", line), err)
+			if !cl.ContinueOnError {
+				return false, fmt.Errorf("statement at line %d failed: %w", line, err)
+			}
+		}
+		return true, nil
+	}
+
+	cl.pending = ""
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxStatementSize)
+	// start is the line the pending statement begins at
+	var start int
+Lines:
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" || strings.HasPrefix(input, "--") {
+			continue
+		}
+		if cl.pending == "" {
+			start = lineNo
+		}
+		for i, statement := range cl.readStatements(input) {
+			line := lineNo
+			if i == 0 {
+				line = start
+			}
+			if ok, err := run(statement, line); !ok {
+				cl.pending = ""
+				if err != nil {
+					return err
+				}
+				break Lines
+			}
+			start = lineNo
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if statement := strings.TrimSpace(cl.pending); statement != "" {
+		cl.pending = ""
+		if _, err := run(statement, start); err != nil {
+			return err
+		}
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d statement(s) failed", failed)
+	}
+	return nil
 }
 
 func (cl *CommandLine) executeUse(stmt *geminiql.UseStatement) error {
@@ -284,27 +597,50 @@ func (cl *CommandLine) executeUse(stmt *geminiql.UseStatement) error {
 }
 
 func (cl *CommandLine) executePrecision(stmt *geminiql.PrecisionStatement) error {
-	precision := strings.ToLower(stmt.Precision)
-	switch precision {
-	case "":
-		cl.Precision = "ns"
-	case "h", "m", "s", "ms", "u", "ns", "rfc3339":
-		cl.Precision = precision
-	default:
-		return fmt.Errorf("unknown precision %q. precision must be rfc3339, h, m, s, ms, u or ns", precision)
+	return cl.applySetting("precision", stmt.Precision)
+}
+
+func (cl *CommandLine) executePager(stmt *geminiql.PagerStatement) error {
+	if stmt.Pager != "" {
+		return cl.applySetting("pager", stmt.Pager)
 	}
-	return nil
+	return cl.applySetting("pager", formatBool(cl.pager == ""))
 }
 
 func (cl *CommandLine) executeHelp(stmt *geminiql.HelpStatement) error {
 	fmt.Println(
 		`Usage:
-  exit/quit/\q/ctrl-c/ctrl-d quit the openGemini shell
+  statements sent to the server end with ';' and may span multiple lines, the shell commands below don't need it
+
+  exit/quit/\q/ctrl-d        quit the openGemini shell
+  ctrl-c                     cancel the running query, or clear the input at the prompt
   timer                      display execution time, type to turn on or off
   debug                      display http request interaction content, type to turn on or off
   prompt                     enable command line reminder and suggestion, type to turn on or off
   vertical                   print query output rows vertically, type to turn on or off
+  \o [>>] [file]             write query output to the file, >> appends to it, \o alone writes to the terminal again
+  \tee [>>] [file]           write query output to both the terminal and the file
+  \set [name [value]]        set a variable or list them, :name, :'name' and :"name" are replaced by the value
+                             as it is, as a string or as an identifier, and $name is bound on the server
+  \unset <name>              remove the variable
+  \save <name> [statement]   save the statement, or the last executed one, in ~/.ts-cli/queries.toml
+  \run <name> [k=v[, ...]]   run the saved query, the variables given override the ones set by \set
+  \list                      list the saved queries
+  watch <N> <statement>      run the statement every N seconds and highlight the changes, ctrl-c stops it
+  pager [on|off|<cmd>]       page the output longer than the terminal through $PAGER, less -S or the command
+  set <key>=<value>[, ...]   change session settings, e.g. set timer=on, float_precision=4, null_display='(null)'
+  set validate=on            check the syntax of the statements before sending them to the server
+  set prompt_template='...'  prompt with the placeholders {user}, {host}, {port}, {db}, {rp} and {profile}
+  set theme=<name>           colors of the syntax highlighting at the prompt: dark, light or off
+  show settings              show all session settings and their current values
+  history [N]                show the last N statements of the history, ctrl-r searches the history
+  format [name]              set or show the output format: table, column, csv, tsv, markdown, json or pretty-json
+  chunked                    stream query results in chunks as they arrive, type to turn on or off
+  chunk_size <size>          number of points per chunk in chunked mode, 0 uses the server default
   auth                       prompt for username and password
+  connect <host>[:port]      connect to another server, --ssl uses https and --user <name> prompts for the password,
+                             the current connection is kept if the server doesn't answer
+  status                     show the server, its version, the user, the database and the TLS settings
   use <db>[.rp]              set current database and optional retention policy
   precision <format>         specifies the format of the timestamp: rfc3339, h, m, s, ms, u or ns
   show cluster               show cluster node status information
@@ -334,50 +670,71 @@ func (cl *CommandLine) executeAuth(stmt *geminiql.AuthStatement) error {
 
 func (cl *CommandLine) executeTimer(stmt *geminiql.TimerStatement) error {
 	// switch timer model enable or disable
-	cl.timer = !cl.timer
-	displayFlag := "disabled"
-	if cl.timer {
-		displayFlag = "enabled"
-	}
-	fmt.Printf("Timer is %s\n", displayFlag)
-	return nil
+	return cl.applySetting("timer", strconv.FormatBool(!cl.timer))
 }
 
 func (cl *CommandLine) executeDebug(stmt *geminiql.DebugStatement) error {
 	// switch debug model enable or disable
-	cl.debug = !cl.debug
-	displayFlag := "disabled"
-	if cl.debug {
-		displayFlag = "enabled"
-	}
-	fmt.Printf("Debug is %s\n", displayFlag)
-	cl.httpClient.SetDebug(cl.debug)
-	return nil
+	return cl.applySetting("debug", strconv.FormatBool(!cl.debug))
 }
 
 func (cl *CommandLine) executePrompt(stmt *geminiql.PromptStatement) error {
 	// switch suggest model enable or disable
-	cl.suggest = !cl.suggest
-	displayFlag := "disabled"
-	if cl.suggest {
-		displayFlag = "enabled"
-	}
-	fmt.Printf("Prompt is %s\n", displayFlag)
-	cl.prompt.SwitchCompleter(cl.suggest)
-	return nil
+	return cl.applySetting("prompt", strconv.FormatBool(!cl.suggest))
 }
 
 func (cl *CommandLine) executeInsert(stmt *geminiql.InsertStatement) error {
+	defer cl.metadata.invalidate()
 	return cl.httpClient.Write(context.Background(), cl.Database, cl.RetentionPolicy, stmt.LineProtocol, cl.Precision)
 }
 
+func (cl *CommandLine) executeChunked(stmt *geminiql.ChunkedStatement) error {
+	// switch chunked model enable or disable
+	return cl.applySetting("chunked", strconv.FormatBool(!cl.chunked))
+}
+
+func (cl *CommandLine) executeChunkSize(stmt *geminiql.ChunkSizeStatement) error {
+	return cl.applySetting("chunk_size", strconv.FormatInt(stmt.Size, 10))
+}
+
 func (cl *CommandLine) executeVertical(stmt *geminiql.VerticalStatement) error {
-	cl.DisplayVertical = !cl.DisplayVertical
-	displayFlag := "disabled"
-	if cl.DisplayVertical {
-		displayFlag = "enabled"
+	return cl.applySetting("vertical", strconv.FormatBool(!cl.DisplayVertical))
+}
+
+func (cl *CommandLine) executeFormat(stmt *geminiql.FormatStatement) error {
+	if stmt.Format == "" {
+		fmt.Printf("Format is %s\n", cl.format)
+		return nil
+	}
+	return cl.applySetting("format", stmt.Format)
+}
+
+func (cl *CommandLine) executeSet(stmt *geminiql.SetStatement) error {
+	for _, kv := range stmt.KVS {
+		if err := cl.applySetting(fmt.Sprintf("%v", kv.First()), fmt.Sprintf("%v", kv.Second())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cl *CommandLine) executeShowSettings(stmt *geminiql.ShowSettingsStatement) error {
+	cl.showSettings()
+	return nil
+}
+
+func (cl *CommandLine) executeHistory(stmt *geminiql.HistoryStatement) error {
+	if cl.history == nil {
+		return errors.New("history is only available in the interactive shell")
+	}
+	entries := cl.history.Entries()
+	start := 0
+	if stmt.Limit > 0 && int(stmt.Limit) < len(entries) {
+		start = len(entries) - int(stmt.Limit)
+	}
+	for i := start; i < len(entries); i++ {
+		fmt.Printf("%5d  %s\n", i+1, entries[i])
 	}
-	fmt.Printf("Vertical is %s\n", displayFlag)
 	return nil
 }
 
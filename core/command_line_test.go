@@ -0,0 +1,180 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/openGemini-cli/geminiql"
+	"github.com/openGemini/openGemini-cli/prompt"
+)
+
+type recordedQuery struct {
+	database string
+	command  string
+	params   string
+}
+
+func newTestCommandLine(t *testing.T, cfg *CommandLineConfig) (*CommandLine, *[]recordedQuery) {
+	var queries []recordedQuery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		require.NoError(t, r.ParseForm())
+		queries = append(queries, recordedQuery{database: r.Form.Get("db"), command: r.Form.Get("q"), params: r.Form.Get("params")})
+		if strings.HasPrefix(r.Form.Get("q"), "BAD") {
+			_, _ = w.Write([]byte(`{"error":"error parsing query"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"statement_id":0}]}`))
+	}))
+	t.Cleanup(server.Close)
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 1000
+	}
+	cl := &CommandLine{
+		CommandLineConfig: cfg,
+		parser:            geminiql.QLNewParser(),
+		httpClient:        newTestHttpClient(server),
+		valueFormat:       valueFormat{floatPrecision: -1},
+	}
+	return cl, &queries
+}
+
+func TestCommandLine_RunStatements(t *testing.T) {
+	cl, queries := newTestCommandLine(t, &CommandLineConfig{})
+	err := cl.RunStatements(strings.NewReader("-- comment\nuse db0\n\nSHOW MEASUREMENTS;\nexit\nSHOW DATABASES;\n"))
+	require.NoError(t, err)
+	require.Equal(t, []recordedQuery{{database: "db0", command: "SHOW MEASUREMENTS"}}, *queries)
+}
+
+func TestCommandLine_RunStatementsStopOnError(t *testing.T) {
+	cl, queries := newTestCommandLine(t, &CommandLineConfig{})
+	err := cl.RunStatements(strings.NewReader("SHOW DATABASES;\nBAD QUERY;\nSHOW USERS;\n"))
+	require.ErrorContains(t, err, "line 2")
+	require.Len(t, *queries, 2)
+}
+
+func TestCommandLine_RunStatementsContinueOnError(t *testing.T) {
+	cl, queries := newTestCommandLine(t, &CommandLineConfig{ContinueOnError: true})
+	err := cl.RunStatements(strings.NewReader("BAD QUERY;\nSHOW USERS;\nBAD QUERY AGAIN;\n"))
+	require.ErrorContains(t, err, "2 statement(s) failed")
+	require.Len(t, *queries, 3)
+}
+
+func TestCommandLine_RunStatementsMultiLine(t *testing.T) {
+	cl, queries := newTestCommandLine(t, &CommandLineConfig{Database: "db0"})
+	err := cl.RunStatements(strings.NewReader("SELECT value\nFROM m;\nSHOW USERS; SHOW\nDATABASES\n"))
+	require.NoError(t, err)
+	// the statement without a `;` at the end of the script is run too
+	require.Equal(t, []recordedQuery{
+		{database: "db0", command: "SELECT value\nFROM m"},
+		{database: "db0", command: "SHOW USERS"},
+		{database: "db0", command: "SHOW\nDATABASES"},
+	}, *queries)
+
+	cl, _ = newTestCommandLine(t, &CommandLineConfig{})
+	err = cl.RunStatements(strings.NewReader("SHOW DATABASES;\n\nBAD\nQUERY;\n"))
+	require.ErrorContains(t, err, "statement at line 3 failed")
+}
+
+func TestCommandLine_ChunkedLongerThanTimeout(t *testing.T) {
+	cl, _ := newTestCommandLine(t, &CommandLineConfig{Timeout: 150})
+	cl.httpClient = newTestHttpClient(newSlowChunkServer(t, 0, 100*time.Millisecond, 4))
+	cl.httpClient.SetTimeout(150 * time.Millisecond)
+	require.NoError(t, cl.execute("chunked"))
+	output := captureStdout(t, func() {
+		require.NoError(t, cl.execute("SELECT * FROM mst"))
+	})
+	require.Equal(t, 4, strings.Count(output, "name: mst"))
+}
+
+func TestCommandLine_RunExecute(t *testing.T) {
+	cl, queries := newTestCommandLine(t, &CommandLineConfig{Execute: "SHOW DATABASES", Database: "db1"})
+	require.NoError(t, cl.Run())
+	require.Equal(t, []recordedQuery{{database: "db1", command: "SHOW DATABASES"}}, *queries)
+}
+
+func TestCommandLine_ExecuteHistory(t *testing.T) {
+	cl, _ := newTestCommandLine(t, &CommandLineConfig{})
+	require.ErrorContains(t, cl.execute("history"), "only available in the interactive shell")
+
+	cl.history = prompt.NewHistory(filepath.Join(t.TempDir(), "history"), 10)
+	for _, line := range []string{"show databases", "use db0", "show measurements"} {
+		require.NoError(t, cl.history.Add(line))
+	}
+	require.Equal(t, "    2  use db0\n    3  show measurements\n", captureStdout(t, func() {
+		require.NoError(t, cl.execute("history 2"))
+	}))
+	require.Equal(t, "    1  show databases\n    2  use db0\n    3  show measurements\n", captureStdout(t, func() {
+		require.NoError(t, cl.execute("history"))
+	}))
+}
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+	var output = make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		output <- string(data)
+	}()
+	fn()
+	require.NoError(t, writer.Close())
+	return <-output
+}
+
+func TestCommandLine_ReadStatements(t *testing.T) {
+	cl, _ := newTestCommandLine(t, &CommandLineConfig{})
+	require.Empty(t, cl.readStatements("SELECT *"))
+	require.Empty(t, cl.readStatements("FROM cpu WHERE host = 'a;"))
+	require.Equal(t, []string{"SELECT *\nFROM cpu WHERE host = 'a;\nb'"}, cl.readStatements("b'; SHOW"))
+	require.Equal(t, "SHOW", cl.pending)
+	require.Equal(t, []string{"SHOW\nDATABASES"}, cl.readStatements("DATABASES;"))
+	require.Empty(t, cl.pending)
+
+	// shell commands don't need a terminator
+	require.Equal(t, []string{"use db0"}, cl.readStatements("use db0"))
+	require.Equal(t, []string{"SHOW DATABASES", "timer"}, cl.readStatements("SHOW DATABASES; timer"))
+	require.Equal(t, []string{"insert cpu value=1"}, cl.readStatements("insert cpu value=1;"))
+	require.Equal(t, []string{"exit"}, cl.readStatements("exit"))
+	require.Empty(t, cl.pending)
+}
+
+func TestCommandLine_RunStatementsSeparated(t *testing.T) {
+	cl, queries := newTestCommandLine(t, &CommandLineConfig{})
+	err := cl.RunStatements(strings.NewReader("use db0; SHOW MEASUREMENTS;\nSHOW USERS; SHOW DATABASES\n"))
+	require.NoError(t, err)
+	require.Equal(t, []recordedQuery{
+		{database: "db0", command: "SHOW MEASUREMENTS"},
+		{database: "db0", command: "SHOW USERS"},
+		{database: "db0", command: "SHOW DATABASES"},
+	}, *queries)
+}
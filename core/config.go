@@ -14,6 +14,8 @@
 
 package core
 
+import "time"
+
 type CommandLineConfig struct {
 	Host             string
 	Port             int
@@ -33,4 +35,15 @@ type CommandLineConfig struct {
 	Precision        string
 	TimeMultiplier   int64
 	DisplayVertical  bool
+	OutputFormat     string
+	Execute          string
+	ScriptFile       string
+	ContinueOnError  bool
+	HistorySize      int
+	Pager            string
+	Theme            string
+	PromptTemplate   string
+	Profile          string
+	Production       bool
+	Wait             time.Duration
 }
@@ -0,0 +1,159 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	"github.com/openGemini/openGemini-cli/common"
+	"github.com/openGemini/openGemini-cli/geminiql"
+)
+
+// connectUsage is reported when the arguments of `connect` are invalid
+const connectUsage = "usage: connect <host>[:port] [--ssl] [--insecure-tls] [--user <username>] [--password <password>]"
+
+// parseConnectArgs returns the connection settings of `connect`, the settings not given by the
+// arguments like the certificates and the timeout are copied from the current connection. The
+// profile and its production mark belong to the current server and are not carried over
+func parseConnectArgs(current *CommandLineConfig, args []string) (*CommandLineConfig, error) {
+	flags := pflag.NewFlagSet("connect", pflag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	ssl := flags.BoolP("ssl", "s", false, "use https for connecting to openGemini")
+	insecureTls := flags.BoolP("insecure-tls", "i", false, "ignore ssl verification")
+	username := flags.StringP("user", "u", "", "username to connect to openGemini")
+	password := flags.StringP("password", "P", "", "password to connect to openGemini")
+	if err := flags.Parse(args); err != nil {
+		return nil, fmt.Errorf("%w, %s", err, connectUsage)
+	}
+	if flags.NArg() != 1 {
+		return nil, errors.New(connectUsage)
+	}
+
+	host, port := flags.Arg(0), common.DefaultHttpPort
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		host = h
+		if port, err = strconv.Atoi(p); err != nil || port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("invalid port %q", p)
+		}
+	}
+	host = strings.Trim(host, "[]")
+	if host == "" {
+		return nil, errors.New(connectUsage)
+	}
+
+	cfg := *current
+	cfg.Host = host
+	cfg.Port = port
+	cfg.UnixSocket = ""
+	cfg.EnableTls = *ssl
+	cfg.InsecureTls = *insecureTls
+	cfg.Profile = ""
+	cfg.Production = false
+	if flags.Changed("user") {
+		cfg.Username = *username
+		cfg.Password = *password
+		if !flags.Changed("password") && term.IsTerminal(int(os.Stdin.Fd())) {
+			fmt.Printf("password: ")
+			typed, _ := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Printf("\n")
+			cfg.Password = string(typed)
+		}
+	}
+	return &cfg, nil
+}
+
+// executeConnect switches the shell to another server, the current connection is kept unless
+// the new server answers the ping
+func (cl *CommandLine) executeConnect(stmt *geminiql.ConnectStatement) error {
+	cfg, err := parseConnectArgs(cl.CommandLineConfig, stmt.Args)
+	if err != nil {
+		return err
+	}
+	client, err := NewHttpClient(cfg)
+	if err != nil {
+		return err
+	}
+	client.SetDebug(cl.debug)
+	version, err := client.Ping()
+	if err != nil {
+		return fmt.Errorf("connect to %s failed, still connected to %s: %w", serverURL(cfg), serverURL(cl.CommandLineConfig), err)
+	}
+
+	*cl.CommandLineConfig = *cfg
+	cl.httpClient = client
+	cl.metadata.reset(client)
+	if version == "" {
+		version = "unknown"
+	}
+	fmt.Printf("Connected to %s version %s\n", serverURL(cfg), version)
+	return nil
+}
+
+// executeStatus shows the connection of the shell, the version is asked from the server
+func (cl *CommandLine) executeStatus(stmt *geminiql.StatusStatement) error {
+	version, err := cl.httpClient.Ping()
+	switch {
+	case err != nil:
+		version = "unreachable: " + err.Error()
+	case version == "":
+		version = "unknown"
+	}
+	precision, _ := lookupSetting("precision")
+	var rows = [][2]string{
+		{"server", serverURL(cl.CommandLineConfig)},
+		{"version", version},
+		{"user", cl.Username},
+		{"database", cl.Database},
+		{"retention policy", cl.RetentionPolicy},
+		{"precision", precision.get(cl)},
+		{"tls", formatBool(cl.EnableTls)},
+	}
+	if cl.EnableTls {
+		rows = append(rows,
+			[2]string{"insecure tls", formatBool(cl.InsecureTls)},
+			[2]string{"insecure hostname", formatBool(cl.InsecureHostname)},
+			[2]string{"ca certificate", cl.CACert},
+			[2]string{"client certificate", cl.Cert},
+		)
+	}
+	var series = &opengemini.Series{Columns: []string{"name", "value"}}
+	for _, row := range rows {
+		series.Values = append(series.Values, opengemini.SeriesValue{row[0], row[1]})
+	}
+	cl.output(&opengemini.SeriesResult{Series: []*opengemini.Series{series}})
+	return nil
+}
+
+// serverURL is the address of the server shown to the user
+func serverURL(cfg *CommandLineConfig) string {
+	if cfg.UnixSocket != "" {
+		return "unix://" + cfg.UnixSocket
+	}
+	schema := "http"
+	if cfg.EnableTls {
+		schema = "https"
+	}
+	return schema + "://" + net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/openGemini-cli/prompt"
+)
+
+func newPingServer(t *testing.T, version string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.Header().Set("X-Geminidb-Version", version)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"statement_id":0}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestParseConnectArgs(t *testing.T) {
+	current := &CommandLineConfig{Host: "localhost", Port: 8086, UnixSocket: "/tmp/gemini.sock", Username: "admin", Password: "secret", Database: "db0", Timeout: 1000,
+		Profile: "prod", Production: true}
+
+	cfg, err := parseConnectArgs(current, []string{"db.example.com"})
+	require.NoError(t, err)
+	require.Equal(t, "db.example.com", cfg.Host)
+	require.Equal(t, 8086, cfg.Port)
+	require.Empty(t, cfg.UnixSocket)
+	require.Equal(t, "admin", cfg.Username)
+	require.Equal(t, "db0", cfg.Database)
+	// the new server is not the one of the profile
+	require.Empty(t, cfg.Profile)
+	require.False(t, cfg.Production)
+
+	cfg, err = parseConnectArgs(current, []string{"[::1]:8443", "--ssl", "--user", "reader", "--password", "pass"})
+	require.NoError(t, err)
+	require.Equal(t, "::1", cfg.Host)
+	require.Equal(t, 8443, cfg.Port)
+	require.True(t, cfg.EnableTls)
+	require.Equal(t, "reader", cfg.Username)
+	require.Equal(t, "pass", cfg.Password)
+	// the current connection is never changed by the parsing
+	require.Equal(t, "localhost", current.Host)
+
+	_, err = parseConnectArgs(current, []string{"localhost:port"})
+	require.ErrorContains(t, err, "invalid port")
+	_, err = parseConnectArgs(current, []string{"a", "b"})
+	require.ErrorContains(t, err, "usage: connect")
+	_, err = parseConnectArgs(current, []string{"localhost", "--unknown"})
+	require.ErrorContains(t, err, "usage: connect")
+}
+
+func TestCommandLine_Connect(t *testing.T) {
+	cl, queries := newTestCommandLine(t, &CommandLineConfig{Host: "old.example.com", Port: 8086, Database: "db0",
+		Profile: "prod", Production: true})
+	server := newPingServer(t, "v1.4.0")
+	address, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, cl.execute("connect "+address.Host))
+	require.Equal(t, "127.0.0.1", cl.Host)
+	require.Equal(t, "db0", cl.Database)
+	_, style := cl.promptPrefix()
+	require.Equal(t, prompt.PrefixPlain, style)
+	require.NoError(t, cl.execute("SHOW DATABASES"))
+	// the query is sent to the new server instead of the old one
+	require.Empty(t, *queries)
+
+	// the server doesn't answer, the current connection is kept
+	server.Close()
+	err = cl.execute(`\connect ` + address.Host)
+	require.ErrorContains(t, err, "still connected to http://"+address.Host)
+	require.Equal(t, "127.0.0.1", cl.Host)
+}
+
+func TestCommandLine_Status(t *testing.T) {
+	server := newPingServer(t, "v1.4.0")
+	cl := &CommandLine{
+		CommandLineConfig: &CommandLineConfig{Host: "localhost", Port: 8086, Username: "admin", Database: "db0"},
+		httpClient:        newTestHttpClient(server),
+	}
+	var buf strings.Builder
+	cl.captured = &buf
+	require.NoError(t, cl.executeStatus(nil))
+	require.Contains(t, buf.String(), "http://localhost:8086")
+	require.Contains(t, buf.String(), "v1.4.0")
+	require.Contains(t, buf.String(), "admin")
+	require.NotContains(t, buf.String(), "ca certificate")
+
+	server.Close()
+	buf.Reset()
+	require.NoError(t, cl.executeStatus(nil))
+	require.Contains(t, buf.String(), "unreachable")
+}
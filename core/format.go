@@ -0,0 +1,190 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/openGemini/opengemini-client-go/opengemini"
+)
+
+// OutputFormat is the format query results are rendered with
+type OutputFormat string
+
+const (
+	OutputFormatTable      OutputFormat = "table"
+	OutputFormatColumn     OutputFormat = "column"
+	OutputFormatCSV        OutputFormat = "csv"
+	OutputFormatTSV        OutputFormat = "tsv"
+	OutputFormatMarkdown   OutputFormat = "markdown"
+	OutputFormatJSON       OutputFormat = "json"
+	OutputFormatPrettyJSON OutputFormat = "pretty-json"
+)
+
+var outputFormats = []OutputFormat{
+	OutputFormatTable,
+	OutputFormatColumn,
+	OutputFormatCSV,
+	OutputFormatTSV,
+	OutputFormatMarkdown,
+	OutputFormatJSON,
+	OutputFormatPrettyJSON,
+}
+
+// ParseOutputFormat validates the format name, an empty name is the default table format
+func ParseOutputFormat(name string) (OutputFormat, error) {
+	if name == "" {
+		return OutputFormatTable, nil
+	}
+	format := OutputFormat(strings.ToLower(name))
+	if !slices.Contains(outputFormats, format) {
+		var names = make([]string, 0, len(outputFormats))
+		for _, f := range outputFormats {
+			names = append(names, string(f))
+		}
+		return "", fmt.Errorf("unknown format %q. format must be %s", name, strings.Join(names, ", "))
+	}
+	return format, nil
+}
+
+// valueFormat controls how query result values are rendered
+type valueFormat struct {
+	// digits after the decimal point of float values, negative keeps the full precision of the value
+	floatPrecision int
+	// string rendered for null values
+	nullDisplay string
+}
+
+func (vf valueFormat) format(val interface{}) string {
+	switch cv := val.(type) {
+	case nil:
+		return vf.nullDisplay
+	case json.Number:
+		if vf.floatPrecision < 0 || !strings.ContainsAny(cv.String(), ".eE") {
+			return cv.String()
+		}
+		f, err := cv.Float64()
+		if err != nil {
+			return cv.String()
+		}
+		return strconv.FormatFloat(f, 'f', vf.floatPrecision, 64)
+	case int64:
+		return strconv.FormatInt(cv, 10)
+	case float32:
+		return strconv.FormatFloat(float64(cv), 'f', vf.floatPrecision, 32)
+	case float64:
+		return strconv.FormatFloat(cv, 'f', vf.floatPrecision, 64)
+	case string:
+		return cv
+	case bool:
+		return fmt.Sprintf("%t", cv)
+	default:
+		return fmt.Sprintf("%v", cv)
+	}
+}
+
+func seriesTags(series *opengemini.Series) []string {
+	var tags = make([]string, 0, len(series.Tags))
+	for k, v := range series.Tags {
+		tags = append(tags, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func writeJSON(w io.Writer, result *opengemini.SeriesResult, pretty bool) error {
+	encoder := json.NewEncoder(w)
+	if pretty {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(result)
+}
+
+// writeSeparated writes every series as delimiter separated values, each series starts with
+// a header line and every row is prefixed with the series name and tags
+func writeSeparated(w io.Writer, result *opengemini.SeriesResult, delimiter rune, vf valueFormat) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = delimiter
+	for _, series := range result.Series {
+		if len(series.Columns) == 0 {
+			continue
+		}
+		if err := writer.Write(append([]string{"name", "tags"}, series.Columns...)); err != nil {
+			return err
+		}
+		tags := strings.Join(seriesTags(series), ",")
+		for _, value := range series.Values {
+			var record = make([]string, 0, len(value)+2)
+			record = append(record, series.Name, tags)
+			for _, val := range value {
+				record = append(record, vf.format(val))
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeMarkdown(w io.Writer, series *opengemini.Series, vf valueFormat) error {
+	table := tablewriter.NewTable(w,
+		tablewriter.WithRenderer(renderer.NewMarkdown()),
+		tablewriter.WithEastAsian(false),
+		tablewriter.WithHeaderAutoFormat(tw.Off),
+	)
+	table.Header(series.Columns)
+	for _, value := range series.Values {
+		tuple := make([]string, len(value))
+		for i, val := range value {
+			tuple[i] = vf.format(val)
+		}
+		if err := table.Append(tuple); err != nil {
+			return err
+		}
+	}
+	return table.Render()
+}
+
+// writeColumn writes the series as whitespace aligned columns without borders
+func writeColumn(w io.Writer, series *opengemini.Series, vf valueFormat) error {
+	writer := tabwriter.NewWriter(w, 0, 8, 1, ' ', 0)
+	var underline = make([]string, len(series.Columns))
+	for i, column := range series.Columns {
+		underline[i] = strings.Repeat("-", maxColumnNameWidth([]string{column}))
+	}
+	_, _ = fmt.Fprintln(writer, strings.Join(series.Columns, "\t"))
+	_, _ = fmt.Fprintln(writer, strings.Join(underline, "\t"))
+	for _, value := range series.Values {
+		tuple := make([]string, len(value))
+		for i, val := range value {
+			tuple[i] = vf.format(val)
+		}
+		_, _ = fmt.Fprintln(writer, strings.Join(tuple, "\t"))
+	}
+	return writer.Flush()
+}
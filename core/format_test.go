@@ -0,0 +1,105 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+	"github.com/stretchr/testify/require"
+)
+
+func testSeriesResult() *opengemini.SeriesResult {
+	return &opengemini.SeriesResult{
+		Series: []*opengemini.Series{
+			{
+				Name:    "cpu",
+				Tags:    map[string]string{"region": "us", "host": "a"},
+				Columns: []string{"time", "usage"},
+				Values: opengemini.SeriesValues{
+					{int64(1), "idle"},
+					{int64(2), "busy, high"},
+				},
+			},
+		},
+	}
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	format, err := ParseOutputFormat("")
+	require.NoError(t, err)
+	require.Equal(t, OutputFormatTable, format)
+
+	format, err = ParseOutputFormat("CSV")
+	require.NoError(t, err)
+	require.Equal(t, OutputFormatCSV, format)
+
+	_, err = ParseOutputFormat("xml")
+	require.ErrorContains(t, err, `unknown format "xml"`)
+}
+
+func TestValueFormat(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		value     interface{}
+		precision int
+		expect    string
+	}{
+		{name: "nil", value: nil, precision: -1, expect: "(null)"},
+		{name: "float full precision", value: 3.14159, precision: -1, expect: "3.14159"},
+		{name: "float fixed precision", value: 3.14159, precision: 2, expect: "3.14"},
+		{name: "number float full precision", value: json.Number("3.14159"), precision: -1, expect: "3.14159"},
+		{name: "number float fixed precision", value: json.Number("3.14159"), precision: 4, expect: "3.1416"},
+		{name: "number exponent", value: json.Number("1.5e3"), precision: 0, expect: "1500"},
+		{name: "number int64 above 2^53", value: json.Number("9007199254740993"), precision: 2, expect: "9007199254740993"},
+		{name: "int64", value: int64(-42), precision: 2, expect: "-42"},
+		{name: "bool", value: true, precision: -1, expect: "true"},
+		{name: "string", value: "3.14159", precision: 2, expect: "3.14159"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, valueFormat{floatPrecision: tc.precision, nullDisplay: "(null)"}.format(tc.value))
+		})
+	}
+}
+
+func TestWriteSeparated(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeSeparated(&buf, testSeriesResult(), ',', valueFormat{floatPrecision: -1}))
+	require.Equal(t, "name,tags,time,usage\ncpu,\"host=a,region=us\",1,idle\ncpu,\"host=a,region=us\",2,\"busy, high\"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeSeparated(&buf, testSeriesResult(), '\t', valueFormat{floatPrecision: -1}))
+	require.Equal(t, "name\ttags\ttime\tusage\ncpu\thost=a,region=us\t1\tidle\ncpu\thost=a,region=us\t2\tbusy, high\n", buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeJSON(&buf, testSeriesResult(), false))
+	require.Equal(t, `{"series":[{"name":"cpu","tags":{"host":"a","region":"us"},"columns":["time","usage"],"values":[[1,"idle"],[2,"busy, high"]]}]}`+"\n", buf.String())
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeMarkdown(&buf, testSeriesResult().Series[0], valueFormat{floatPrecision: -1}))
+	require.Equal(t, "| time |   usage    |\n|:----:|:----------:|\n|  1   |    idle    |\n|  2   | busy, high |\n", buf.String())
+}
+
+func TestWriteColumn(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeColumn(&buf, testSeriesResult().Series[0], valueFormat{floatPrecision: -1}))
+	require.Equal(t, "time usage\n---- -----\n1    idle\n2    busy, high\n", buf.String())
+}
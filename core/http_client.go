@@ -15,6 +15,7 @@
 package core
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -23,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/http"
 	"net/http/httputil"
@@ -40,8 +42,10 @@ var _ HttpClient = (*HttpClientCreator)(nil)
 type HttpClient interface {
 	SetDebug(debug bool)
 	SetAuth(username, password string)
-	Ping() error
+	SetTimeout(timeout time.Duration)
+	Ping() (string, error)
 	Query(context.Context, *opengemini.Query) (*opengemini.QueryResult, error)
+	QueryChunked(ctx context.Context, query *opengemini.Query, chunkSize int, fn func(*opengemini.QueryResult) error) error
 	Write(ctx context.Context, database, retentionPolicy, raw, precision string) error
 }
 
@@ -52,6 +56,14 @@ type HttpClientCreator struct {
 	debug    bool
 }
 
+// chunkedClient is the client of the chunked queries, it shares the connections of the client
+// without its timeout, which would cut the stream of a long query off
+func (h *HttpClientCreator) chunkedClient() *http.Client {
+	client := *h.client
+	client.Timeout = 0
+	return &client
+}
+
 func (h *HttpClientCreator) SetAuth(username, password string) {
 	h.basic = base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
 }
@@ -60,6 +72,10 @@ func (h *HttpClientCreator) SetDebug(debug bool) {
 	h.debug = debug
 }
 
+func (h *HttpClientCreator) SetTimeout(timeout time.Duration) {
+	h.client.Timeout = timeout
+}
+
 func NewHttpClient(cfg *CommandLineConfig) (HttpClient, error) {
 	var client = &HttpClientCreator{client: &http.Client{
 		Timeout: time.Duration(cfg.Timeout) * time.Millisecond,
@@ -131,36 +147,38 @@ func NewHttpClient(cfg *CommandLineConfig) (HttpClient, error) {
 		client.SetAuth(cfg.Username, cfg.Password)
 	}
 
-	client.HostPort = schema + "://" + cfg.Host + ":" + strconv.FormatInt(int64(cfg.Port), 10)
+	client.HostPort = schema + "://" + net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
 
 	client.client.Transport = transport
 	return client, nil
 }
 
-// Ping will check to see if the server is up
-func (h *HttpClientCreator) Ping() error {
+// Ping will check to see if the server is up, and returns the version of the server reported
+// by the X-Geminidb-Version header, empty if the server doesn't report it
+func (h *HttpClientCreator) Ping() (string, error) {
 	var urlPath = h.HostPort + "/ping"
-	response, err := h.innerRequest(context.Background(), http.MethodGet, urlPath, nil)
+	response, err := h.innerRequest(context.Background(), h.client, http.MethodGet, urlPath, nil)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer response.Body.Close()
 	if response.StatusCode != 204 {
-		return fmt.Errorf("ping failed: %s", response.Status)
+		return "", fmt.Errorf("ping failed: %s", response.Status)
 	}
-	return nil
+	version := response.Header.Get("X-Geminidb-Version")
+	if version == "" {
+		// the servers compatible with InfluxDB 1.x report the version in its header
+		version = response.Header.Get("X-Influxdb-Version")
+	}
+	return version, nil
 }
 
 func (h *HttpClientCreator) Query(ctx context.Context, query *opengemini.Query) (*opengemini.QueryResult, error) {
-	urlPath := h.HostPort + "/query"
-
-	var queryValues = make(url.Values)
-	queryValues.Add("db", query.Database)
-	queryValues.Add("rp", query.RetentionPolicy)
-	queryValues.Add("q", query.Command)
-	queryValues.Add("epoch", query.Precision.Epoch())
-
-	response, err := h.innerRequest(ctx, http.MethodPost, urlPath, strings.NewReader(queryValues.Encode()))
+	values, err := queryValues(query)
+	if err != nil {
+		return nil, err
+	}
+	response, err := h.innerQuery(ctx, h.client, values)
 	if err != nil {
 		return nil, err
 	}
@@ -173,14 +191,91 @@ func (h *HttpClientCreator) Query(ctx context.Context, query *opengemini.Query)
 	if response.StatusCode != http.StatusOK {
 		return nil, errors.New("response status_code: " + response.Status + ", body: " + string(data))
 	}
+	// decode numbers as json.Number to keep the precision of int64 values above 2^53
 	var qr = new(opengemini.QueryResult)
-	err = json.Unmarshal(data, qr)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err = decoder.Decode(qr)
 	if err != nil {
 		return nil, err
 	}
 	return qr, nil
 }
 
+// QueryChunked asks the server to stream the result in chunks of chunkSize points and calls fn once
+// for every chunk as soon as it has been decoded, so the whole response is never held in memory.
+// A chunkSize less than or equal to zero lets the server choose its default chunk size.
+func (h *HttpClientCreator) QueryChunked(ctx context.Context, query *opengemini.Query, chunkSize int, fn func(*opengemini.QueryResult) error) error {
+	values, err := queryValues(query)
+	if err != nil {
+		return err
+	}
+	values.Add("chunked", "true")
+	if chunkSize > 0 {
+		values.Add("chunk_size", strconv.Itoa(chunkSize))
+	}
+	// the timeout only bounds the wait for the response headers, the chunks are read for as long
+	// as the query runs and only ctx stops them
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	timeout := h.client.Timeout
+	if timeout <= 0 {
+		timeout = math.MaxInt64
+	}
+	timer := time.AfterFunc(timeout, cancel)
+	response, err := h.innerQuery(ctx, h.chunkedClient(), values)
+	if !timer.Stop() {
+		if err == nil {
+			response.Body.Close()
+		}
+		return fmt.Errorf("no response from the server in %s: %w", timeout, context.DeadlineExceeded)
+	}
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(response.Body)
+		return errors.New("response status_code: " + response.Status + ", body: " + string(data))
+	}
+	decoder := json.NewDecoder(response.Body)
+	decoder.UseNumber()
+	for {
+		var qr = new(opengemini.QueryResult)
+		err = decoder.Decode(qr)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = fn(qr); err != nil {
+			return err
+		}
+	}
+}
+
+func (h *HttpClientCreator) innerQuery(ctx context.Context, client *http.Client, values url.Values) (*http.Response, error) {
+	return h.innerRequest(ctx, client, http.MethodPost, h.HostPort+"/query", strings.NewReader(values.Encode()))
+}
+
+func queryValues(query *opengemini.Query) (url.Values, error) {
+	var values = make(url.Values)
+	values.Add("db", query.Database)
+	values.Add("rp", query.RetentionPolicy)
+	values.Add("q", query.Command)
+	values.Add("epoch", query.Precision.Epoch())
+	if len(query.Params) != 0 {
+		params, err := json.Marshal(query.Params)
+		if err != nil {
+			return nil, fmt.Errorf("encode query params: %w", err)
+		}
+		values.Add("params", string(params))
+	}
+	return values, nil
+}
+
 func (h *HttpClientCreator) Write(ctx context.Context, database, retentionPolicy, raw, precision string) error {
 	urlPath := h.HostPort + "/write"
 	u, err := url.Parse(urlPath)
@@ -194,19 +289,38 @@ func (h *HttpClientCreator) Write(ctx context.Context, database, retentionPolicy
 	writeValues.Add("precision", precision)
 	u.RawQuery = writeValues.Encode()
 
-	response, err := h.innerRequest(ctx, http.MethodPost, u.String(), strings.NewReader(raw))
+	response, err := h.innerRequest(ctx, h.client, http.MethodPost, u.String(), strings.NewReader(raw))
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return errors.New("write failed: " + response.Status)
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+		return &HttpStatusError{StatusCode: response.StatusCode, Status: response.Status, Body: strings.TrimSpace(string(body))}
 	}
 	return nil
 }
 
-func (h *HttpClientCreator) innerRequest(ctx context.Context, method, urlPath string, reader io.Reader) (*http.Response, error) {
+// maxErrorBodySize is the longest response body kept in an HttpStatusError
+const maxErrorBodySize = 4096
+
+// HttpStatusError is returned by Write when the server rejects the points, the status code tells
+// the transient failures like 503 from the invalid points
+type HttpStatusError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *HttpStatusError) Error() string {
+	if e.Body == "" {
+		return "write failed: " + e.Status
+	}
+	return "write failed: " + e.Status + ": " + e.Body
+}
+
+func (h *HttpClientCreator) innerRequest(ctx context.Context, client *http.Client, method, urlPath string, reader io.Reader) (*http.Response, error) {
 	request, err := http.NewRequestWithContext(ctx, method, urlPath, reader)
 	if err != nil {
 		return nil, err
@@ -222,7 +336,7 @@ func (h *HttpClientCreator) innerRequest(ctx context.Context, method, urlPath st
 		fmt.Printf("---------- REQUEST DEBUG ----------\n%s\n---------- REQUEST DEBUG ----------\n", string(dumpRequest))
 	}
 
-	response, err := h.client.Do(request)
+	response, err := client.Do(request)
 
 	if h.debug {
 		dumpResponse, _ := httputil.DumpResponse(response, true)
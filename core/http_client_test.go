@@ -0,0 +1,154 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHttpClient(server *httptest.Server) *HttpClientCreator {
+	return &HttpClientCreator{HostPort: server.URL, client: server.Client()}
+}
+
+func TestHttpClientCreator_QueryChunked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "true", r.Form.Get("chunked"))
+		require.Equal(t, "2", r.Form.Get("chunk_size"))
+		require.Equal(t, "db0", r.Form.Get("db"))
+		require.Equal(t, "SELECT * FROM mst", r.Form.Get("q"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"mst","columns":["time","v"],"values":[[1,"a"],[2,"b"]]}],"partial":true}]}` + "\n"))
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"mst","columns":["time","v"],"values":[[3,"c"]]}]}]}` + "\n"))
+	}))
+	defer server.Close()
+
+	client := newTestHttpClient(server)
+	var chunks []*opengemini.QueryResult
+	err := client.QueryChunked(context.Background(), &opengemini.Query{Database: "db0", Command: "SELECT * FROM mst"}, 2,
+		func(result *opengemini.QueryResult) error {
+			chunks = append(chunks, result)
+			return nil
+		})
+	require.NoError(t, err)
+	require.Len(t, chunks, 2)
+	require.Len(t, chunks[0].Results[0].Series[0].Values, 2)
+	require.Len(t, chunks[1].Results[0].Series[0].Values, 1)
+	require.Equal(t, "c", chunks[1].Results[0].Series[0].Values[0][1])
+}
+
+func TestHttpClientCreator_QueryChunkedDefaultSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "true", r.Form.Get("chunked"))
+		require.False(t, r.Form.Has("chunk_size"))
+		_, _ = w.Write([]byte(`{"results":[{"statement_id":0}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestHttpClient(server)
+	var count int
+	err := client.QueryChunked(context.Background(), &opengemini.Query{Command: "SHOW DATABASES"}, 0,
+		func(result *opengemini.QueryResult) error {
+			count++
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestHttpClientCreator_QueryChunkedError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"error parsing query"}`))
+	}))
+	defer server.Close()
+
+	client := newTestHttpClient(server)
+	err := client.QueryChunked(context.Background(), &opengemini.Query{Command: "SELEC"}, 0,
+		func(result *opengemini.QueryResult) error {
+			t.Fatal("callback must not be called on error response")
+			return nil
+		})
+	require.ErrorContains(t, err, "error parsing query")
+}
+
+// newSlowChunkServer streams the chunks with the delay before each one, and waits for wait
+// before answering
+func newSlowChunkServer(t *testing.T, wait, delay time.Duration, chunks int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(wait):
+		case <-r.Context().Done():
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		for i := 0; i < chunks; i++ {
+			time.Sleep(delay)
+			_, _ = fmt.Fprintf(w, `{"results":[{"statement_id":0,"series":[{"name":"mst","columns":["time","v"],"values":[[%d,1]]}],"partial":%t}]}`+"\n", i, i < chunks-1)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHttpClientCreator_QueryChunkedLongerThanTimeout(t *testing.T) {
+	server := newSlowChunkServer(t, 0, 100*time.Millisecond, 4)
+	client := newTestHttpClient(server)
+	client.SetTimeout(150 * time.Millisecond)
+
+	// the stream lasts longer than the timeout, which only waits for the response headers
+	var count int
+	err := client.QueryChunked(context.Background(), &opengemini.Query{Command: "SELECT * FROM mst"}, 1,
+		func(result *opengemini.QueryResult) error {
+			count++
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
+
+	server = newSlowChunkServer(t, 500*time.Millisecond, 0, 1)
+	client = newTestHttpClient(server)
+	client.SetTimeout(50 * time.Millisecond)
+	err = client.QueryChunked(context.Background(), &opengemini.Query{Command: "SELECT * FROM mst"}, 1,
+		func(result *opengemini.QueryResult) error { return nil })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "no response from the server in 50ms")
+}
+
+func TestHttpClientCreator_QueryKeepsNumberPrecision(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"mst","columns":["time","big","pi"],"values":[[1,9007199254740993,3.14159]]}]}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestHttpClient(server)
+	result, err := client.Query(context.Background(), &opengemini.Query{Command: "SELECT * FROM mst"})
+	require.NoError(t, err)
+	values := result.Results[0].Series[0].Values[0]
+	require.Equal(t, json.Number("9007199254740993"), values[1])
+	require.Equal(t, json.Number("3.14159"), values[2])
+}
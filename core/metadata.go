@@ -0,0 +1,192 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+
+	"github.com/openGemini/openGemini-cli/geminiql"
+)
+
+// metadataTTL is how long the identifiers fetched for the completion are considered fresh
+const metadataTTL = 30 * time.Second
+
+type metadataKey struct {
+	database string
+	command  string
+}
+
+type metadataEntry struct {
+	names     []string
+	fetchedAt time.Time
+	fetching  bool
+}
+
+// metadataCache provides the databases, measurements, tag keys, field keys and tag values of the
+// server to the completer, cached per database. A missing or expired entry is refreshed in the
+// background and the cached names are returned meanwhile, so the completion never waits for the server.
+type metadataCache struct {
+	client   HttpClient
+	database func() string
+	ttl      time.Duration
+	timeout  time.Duration
+
+	mu      sync.Mutex
+	entries map[metadataKey]*metadataEntry
+}
+
+func newMetadataCache(client HttpClient, database func() string, ttl, timeout time.Duration) *metadataCache {
+	return &metadataCache{
+		client:   client,
+		database: database,
+		ttl:      ttl,
+		timeout:  timeout,
+		entries:  make(map[metadataKey]*metadataEntry),
+	}
+}
+
+func (m *metadataCache) Databases() []string {
+	return m.lookup("", "SHOW DATABASES", "name")
+}
+
+func (m *metadataCache) Measurements() []string {
+	database := m.database()
+	if database == "" {
+		return nil
+	}
+	return m.lookup(database, "SHOW MEASUREMENTS", "name")
+}
+
+func (m *metadataCache) TagKeys(measurement string) []string {
+	database := m.database()
+	if database == "" || measurement == "" {
+		return nil
+	}
+	return m.lookup(database, "SHOW TAG KEYS FROM "+geminiql.QuoteIdent(measurement), "tagKey")
+}
+
+func (m *metadataCache) FieldKeys(measurement string) []string {
+	database := m.database()
+	if database == "" || measurement == "" {
+		return nil
+	}
+	return m.lookup(database, "SHOW FIELD KEYS FROM "+geminiql.QuoteIdent(measurement), "fieldKey")
+}
+
+func (m *metadataCache) TagValues(measurement, key string) []string {
+	database := m.database()
+	if database == "" || measurement == "" || key == "" {
+		return nil
+	}
+	command := fmt.Sprintf("SHOW TAG VALUES FROM %s WITH KEY = %s", geminiql.QuoteIdent(measurement), geminiql.QuoteIdent(key))
+	return m.lookup(database, command, "value")
+}
+
+// invalidate expires all entries, they are refreshed the next time they are looked up
+func (m *metadataCache) invalidate() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, entry := range m.entries {
+		entry.fetchedAt = time.Time{}
+	}
+}
+
+// reset drops all entries and looks the names up by the client from now on, it is called when
+// the shell connects to another server
+func (m *metadataCache) reset(client HttpClient) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.client = client
+	m.entries = make(map[metadataKey]*metadataEntry)
+}
+
+// changesSchema reports whether the statement may create or drop databases, measurements or keys
+func changesSchema(statement string) bool {
+	fields := strings.Fields(statement)
+	if len(fields) == 0 {
+		return false
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "SELECT", "SHOW", "EXPLAIN":
+		return false
+	default:
+		return true
+	}
+}
+
+func (m *metadataCache) lookup(database, command, column string) []string {
+	key := metadataKey{database: database, command: command}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	if !ok {
+		entry = &metadataEntry{}
+		m.entries[key] = entry
+	}
+	if !entry.fetching && time.Since(entry.fetchedAt) > m.ttl {
+		entry.fetching = true
+		go m.fetch(m.client, entry, key, column)
+	}
+	return entry.names
+}
+
+// fetch refreshes the entry by the client it was looked up with, the entry is dropped if the
+// shell connects to another server meanwhile
+func (m *metadataCache) fetch(client HttpClient, entry *metadataEntry, key metadataKey, column string) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	response, err := client.Query(ctx, &opengemini.Query{Database: key.database, Command: key.command})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry.fetching = false
+	// a failed refresh keeps the previous names and is retried after the ttl as well
+	entry.fetchedAt = time.Now()
+	if err == nil && response.Error == "" {
+		entry.names = metadataNames(response, column)
+	}
+}
+
+func metadataNames(response *opengemini.QueryResult, column string) []string {
+	var names []string
+	for _, result := range response.Results {
+		for _, series := range result.Series {
+			index := slices.Index(series.Columns, column)
+			if index < 0 {
+				continue
+			}
+			for _, value := range series.Values {
+				if index < len(value) && value[index] != nil {
+					names = append(names, fmt.Sprintf("%v", value[index]))
+				}
+			}
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		requests.Add(1)
+		switch r.Form.Get("q") {
+		case "SHOW DATABASES":
+			_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"databases","columns":["name"],"values":[["db1"],["db0"]]}]}]}`))
+		case "SHOW MEASUREMENTS":
+			require.Equal(t, "db0", r.Form.Get("db"))
+			_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"measurements","columns":["name"],"values":[["cpu"],["my mst"]]}]}]}`))
+		case `SHOW TAG VALUES FROM "my mst" WITH KEY = host`:
+			_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"my mst","columns":["key","value"],"values":[["host","a"],["host","b"]]}]}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"error":"unexpected query"}`))
+		}
+	}))
+	defer server.Close()
+
+	var database string
+	cache := newMetadataCache(newTestHttpClient(server), func() string { return database }, time.Hour, time.Second)
+
+	// the first lookup never waits for the server
+	require.Empty(t, cache.Databases())
+	require.Eventually(t, func() bool { return len(cache.Databases()) == 2 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"db0", "db1"}, cache.Databases())
+	require.Equal(t, int32(1), requests.Load())
+
+	// the measurements need a current database
+	require.Empty(t, cache.Measurements())
+	require.Equal(t, int32(1), requests.Load())
+
+	database = "db0"
+	require.Eventually(t, func() bool { return len(cache.Measurements()) == 2 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"cpu", "my mst"}, cache.Measurements())
+	require.Eventually(t, func() bool { return len(cache.TagValues("my mst", "host")) == 2 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"a", "b"}, cache.TagValues("my mst", "host"))
+
+	// a failed query keeps the cache empty
+	require.Empty(t, cache.FieldKeys("cpu"))
+	require.Eventually(t, func() bool { return requests.Load() == 4 }, time.Second, 10*time.Millisecond)
+	require.Empty(t, cache.FieldKeys("cpu"))
+
+	// expired entries are refreshed in the background and served meanwhile
+	cache.invalidate()
+	require.Equal(t, []string{"db0", "db1"}, cache.Databases())
+	require.Eventually(t, func() bool { return requests.Load() == 5 }, time.Second, 10*time.Millisecond)
+}
+
+func TestChangesSchema(t *testing.T) {
+	require.False(t, changesSchema("SELECT * FROM cpu"))
+	require.False(t, changesSchema("show measurements"))
+	require.True(t, changesSchema("CREATE DATABASE db0"))
+	require.True(t, changesSchema("drop measurement cpu"))
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/openGemini/openGemini-cli/geminiql"
+)
+
+// redirect is the file the query output is written to by `\o`, or copied to by `\tee`
+type redirect struct {
+	file *os.File
+	tee  bool
+}
+
+// outputWriter returns where the query results are rendered to
+func (cl *CommandLine) outputWriter() io.Writer {
+	switch {
+	case cl.captured != nil:
+		return cl.captured
+	case cl.redirect == nil:
+		return os.Stdout
+	case cl.redirect.tee:
+		return io.MultiWriter(os.Stdout, cl.redirect.file)
+	default:
+		return cl.redirect.file
+	}
+}
+
+// resetOutput closes the redirected file and writes the output to the terminal again
+func (cl *CommandLine) resetOutput() error {
+	if cl.redirect == nil {
+		return nil
+	}
+	err := cl.redirect.file.Close()
+	cl.redirect = nil
+	return err
+}
+
+func (cl *CommandLine) executeOutput(stmt *geminiql.OutputStatement) error {
+	if err := cl.resetOutput(); err != nil {
+		return err
+	}
+	if stmt.File == "" {
+		fmt.Println("Output is written to the terminal")
+		return nil
+	}
+	flag := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if stmt.Append {
+		flag = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(stmt.File, flag, 0644)
+	if err != nil {
+		return err
+	}
+	cl.redirect = &redirect{file: file, tee: stmt.Tee}
+	if stmt.Tee {
+		fmt.Printf("Output is written to the terminal and %s\n", stmt.File)
+	} else {
+		fmt.Printf("Output is written to %s\n", stmt.File)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/openGemini-cli/geminiql"
+)
+
+func TestCommandLine_ExecuteOutput(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"databases","columns":["name"],"values":[["db0"]]}]}]}`))
+	}))
+	defer server.Close()
+	cl := &CommandLine{
+		CommandLineConfig: &CommandLineConfig{Timeout: 1000},
+		parser:            geminiql.QLNewParser(),
+		httpClient:        newTestHttpClient(server),
+		format:            OutputFormatCSV,
+		valueFormat:       valueFormat{floatPrecision: -1},
+	}
+	path := filepath.Join(t.TempDir(), "out.csv")
+
+	require.NoError(t, cl.execute(`\o `+path))
+	require.NoError(t, cl.execute("SHOW DATABASES"))
+	require.NoError(t, cl.execute(`\o >> `+path))
+	require.NoError(t, cl.execute("SHOW DATABASES"))
+	require.NoError(t, cl.execute(`\o`))
+	require.Nil(t, cl.redirect)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "name,tags,name\ndatabases,,db0\nname,tags,name\ndatabases,,db0\n", string(content))
+
+	require.NoError(t, cl.execute(`\tee `+path))
+	require.True(t, cl.redirect.tee)
+	require.NoError(t, cl.execute("SHOW DATABASES"))
+	require.NoError(t, cl.execute(`\tee`))
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "name,tags,name\ndatabases,,db0\n", string(content))
+
+	require.Error(t, cl.execute(`\o `+filepath.Join(path, "missing", "out.csv")))
+	require.Nil(t, cl.redirect)
+}
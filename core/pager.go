@@ -0,0 +1,80 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"golang.org/x/term"
+)
+
+// defaultPager is used when $PAGER is not set, -S chops long lines instead of wrapping them
+const defaultPager = "less -S"
+
+// pagerCommand resolves the value of `pager on|off|<cmd>`, on selects $PAGER or the default pager,
+// off or an empty value disables the pager and returns an empty command
+func pagerCommand(value string) string {
+	if value == "" {
+		return ""
+	}
+	enabled, err := parseBool(value)
+	if err != nil {
+		// not a boolean, so it is the command of the pager
+		return value
+	}
+	if !enabled {
+		return ""
+	}
+	if pager := os.Getenv("PAGER"); pager != "" {
+		return pager
+	}
+	return defaultPager
+}
+
+// pageable reports whether the output may go through the pager, which is only the case in the
+// interactive shell writing to a terminal
+func (cl *CommandLine) pageable() bool {
+	if cl.captured != nil || cl.redirect != nil && !cl.redirect.tee {
+		return false
+	}
+	return cl.pager != "" && cl.prompt != nil && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// page writes the output through the pager if it is longer than the terminal height
+func (cl *CommandLine) page(output []byte) {
+	_, height, err := term.GetSize(int(os.Stdout.Fd()))
+	// one line is left for the prompt
+	if err != nil || bytes.Count(output, []byte{'\n'}) < height {
+		_, _ = os.Stdout.Write(output)
+		return
+	}
+	var pager *exec.Cmd
+	if runtime.GOOS == "windows" {
+		pager = exec.Command("cmd", "/C", cl.pager)
+	} else {
+		pager = exec.Command("sh", "-c", cl.pager)
+	}
+	pager.Stdin = bytes.NewReader(output)
+	pager.Stdout = os.Stdout
+	pager.Stderr = os.Stderr
+	if err = pager.Run(); err != nil {
+		fmt.Printf("error: run pager %q: %s\n", cl.pager, err)
+		_, _ = os.Stdout.Write(output)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagerCommand(t *testing.T) {
+	t.Setenv("PAGER", "")
+	require.Equal(t, "", pagerCommand(""))
+	require.Equal(t, defaultPager, pagerCommand("on"))
+	require.Equal(t, "", pagerCommand("off"))
+	require.Equal(t, "more", pagerCommand("more"))
+
+	t.Setenv("PAGER", "most")
+	require.Equal(t, "most", pagerCommand("on"))
+}
+
+func TestCommandLine_PagerStatement(t *testing.T) {
+	t.Setenv("PAGER", "")
+	cl, _ := newTestCommandLine(t, &CommandLineConfig{})
+
+	require.NoError(t, cl.execute("pager"))
+	require.Equal(t, defaultPager, cl.pager)
+	require.NoError(t, cl.execute("pager"))
+	require.Equal(t, "", cl.pager)
+
+	require.NoError(t, cl.execute("pager 'less -RS'"))
+	require.Equal(t, "less -RS", cl.pager)
+	require.NoError(t, cl.execute("set pager=off"))
+	require.Equal(t, "", cl.pager)
+
+	// the output is never paged outside of the interactive shell
+	require.NoError(t, cl.execute("pager on"))
+	require.False(t, cl.pageable())
+}
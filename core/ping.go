@@ -0,0 +1,67 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// the delay between the pings while waiting for the server doubles up to maxPingBackoff
+var (
+	minPingBackoff = 200 * time.Millisecond
+	maxPingBackoff = 5 * time.Second
+)
+
+// waitForServer pings the server and returns its version, the ping is retried with backoff
+// until the server answers or wait has passed, no wait pings only once
+func waitForServer(client HttpClient, wait time.Duration, retrying func(err error, delay time.Duration)) (string, error) {
+	deadline := time.Now().Add(wait)
+	delay := minPingBackoff
+	for {
+		version, err := client.Ping()
+		if err == nil {
+			return version, nil
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return "", err
+		}
+		delay = min(delay, remaining)
+		if retrying != nil {
+			retrying(err, delay)
+		}
+		time.Sleep(delay)
+		delay = min(delay*2, maxPingBackoff)
+	}
+}
+
+// checkServer pings the server at startup, so a wrong host or port is reported before the
+// first statement. The interactive shell started without --wait only reports an unreachable
+// server, so `connect` can still switch to another one.
+func (cl *CommandLine) checkServer(interactive bool) (string, error) {
+	version, err := waitForServer(cl.httpClient, cl.Wait, func(err error, delay time.Duration) {
+		fmt.Fprintf(os.Stderr, "waiting for %s: %s, retry in %s\n", serverURL(cl.CommandLineConfig), err, delay.Round(time.Millisecond))
+	})
+	if err != nil {
+		err = fmt.Errorf("cannot connect to %s: %w", serverURL(cl.CommandLineConfig), err)
+		if !interactive || cl.Wait > 0 {
+			return "", err
+		}
+		printError(os.Stderr, "error: ", err)
+	}
+	return version, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForServer(t *testing.T) {
+	var pings int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pings++
+		if pings < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("X-Geminidb-Version", "v1.4.0")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	client := newTestHttpClient(server)
+
+	// no wait gives up at the first failure
+	_, err := waitForServer(client, 0, nil)
+	require.ErrorContains(t, err, "503")
+	require.Equal(t, 1, pings)
+
+	var delays []time.Duration
+	version, err := waitForServer(client, time.Minute, func(_ error, delay time.Duration) {
+		delays = append(delays, delay)
+	})
+	require.NoError(t, err)
+	require.Equal(t, "v1.4.0", version)
+	require.Equal(t, []time.Duration{minPingBackoff}, delays)
+}
+
+func TestCommandLine_CheckServer(t *testing.T) {
+	server := newPingServer(t, "v1.3.0")
+	cl := &CommandLine{CommandLineConfig: &CommandLineConfig{}, httpClient: newTestHttpClient(server)}
+	version, err := cl.checkServer(false)
+	require.NoError(t, err)
+	require.Equal(t, "v1.3.0", version)
+
+	server.Close()
+	_, err = cl.checkServer(false)
+	require.ErrorContains(t, err, "cannot connect to")
+	// the interactive shell starts anyway, unless it was asked to wait for the server
+	version, err = cl.checkServer(true)
+	require.NoError(t, err)
+	require.Empty(t, version)
+	cl.Wait = time.Millisecond
+	_, err = cl.checkServer(true)
+	require.ErrorContains(t, err, "cannot connect to")
+}
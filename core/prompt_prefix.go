@@ -0,0 +1,81 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/openGemini/openGemini-cli/prompt"
+)
+
+// DefaultPromptTemplate shows the server and the database the statements are sent to
+const DefaultPromptTemplate = "{user}@{host}:{port} {db}.{rp}> "
+
+var promptPlaceholders = []string{"user", "host", "port", "db", "rp", "profile"}
+
+// promptSeparators are dropped together with their placeholder when it is empty,
+// e.g. `{user}@` without a username
+var promptSeparators = []struct {
+	text        string
+	placeholder string
+}{
+	{"{user}@", "user"},
+	{":{port}", "port"},
+	{".{rp}", "rp"},
+	{"{db}.", "db"},
+	{"({profile}) ", "profile"},
+	{"({profile})", "profile"},
+}
+
+// renderPrompt replaces the placeholders like {host} of the template with the values
+func renderPrompt(template string, values map[string]string) string {
+	for _, separator := range promptSeparators {
+		if values[separator.placeholder] == "" {
+			template = strings.ReplaceAll(template, separator.text, "")
+		}
+	}
+	var replacements []string
+	for _, name := range promptPlaceholders {
+		replacements = append(replacements, "{"+name+"}", values[name])
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// promptPrefix renders the prompt template with the current connection, the production profiles
+// and the TLS connections are told apart by the color of the prefix
+func (cl *CommandLine) promptPrefix() (string, prompt.PrefixStyle) {
+	values := map[string]string{
+		"user":    cl.Username,
+		"host":    cl.Host,
+		"port":    strconv.Itoa(cl.Port),
+		"db":      cl.Database,
+		"rp":      cl.RetentionPolicy,
+		"profile": cl.Profile,
+	}
+	if cl.UnixSocket != "" {
+		values["host"] = cl.UnixSocket
+		values["port"] = ""
+	}
+	prefix := renderPrompt(cl.promptTemplate, values)
+	switch {
+	case cl.Production:
+		return prefix, prompt.PrefixProduction
+	case cl.EnableTls:
+		return prefix, prompt.PrefixTLS
+	default:
+		return prefix, prompt.PrefixPlain
+	}
+}
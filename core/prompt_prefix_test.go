@@ -0,0 +1,59 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/openGemini-cli/prompt"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	values := map[string]string{"user": "admin", "host": "localhost", "port": "8086", "db": "db0", "rp": "rp0"}
+	require.Equal(t, "admin@localhost:8086 db0.rp0> ", renderPrompt(DefaultPromptTemplate, values))
+
+	values = map[string]string{"host": "localhost", "port": "8086", "db": "db0"}
+	require.Equal(t, "localhost:8086 db0> ", renderPrompt(DefaultPromptTemplate, values))
+	require.Equal(t, "localhost:8086 > ", renderPrompt(DefaultPromptTemplate, map[string]string{"host": "localhost", "port": "8086"}))
+	require.Equal(t, "[prod] db0> ", renderPrompt("[{profile}] {db}> ", map[string]string{"profile": "prod", "db": "db0"}))
+	require.Equal(t, "db0> ", renderPrompt("({profile}) {db}> ", map[string]string{"db": "db0"}))
+}
+
+func TestCommandLine_PromptPrefix(t *testing.T) {
+	cl, _ := newTestCommandLine(t, &CommandLineConfig{Host: "localhost", Port: 8086})
+	cl.promptTemplate = DefaultPromptTemplate
+
+	prefix, style := cl.promptPrefix()
+	require.Equal(t, "localhost:8086 > ", prefix)
+	require.Equal(t, prompt.PrefixPlain, style)
+
+	// the prefix follows the database selected by use
+	require.NoError(t, cl.execute("use db0.rp0"))
+	prefix, _ = cl.promptPrefix()
+	require.Equal(t, "localhost:8086 db0.rp0> ", prefix)
+
+	require.NoError(t, cl.execute("set prompt_template='{user}@{host}> '"))
+	cl.Username = "admin"
+	cl.EnableTls = true
+	prefix, style = cl.promptPrefix()
+	require.Equal(t, "admin@localhost> ", prefix)
+	require.Equal(t, prompt.PrefixTLS, style)
+
+	cl.Production = true
+	_, style = cl.promptPrefix()
+	require.Equal(t, prompt.PrefixProduction, style)
+}
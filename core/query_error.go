@@ -0,0 +1,134 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/openGemini/openGemini/lib/util/lifted/influx/influxql"
+	"golang.org/x/term"
+)
+
+const (
+	ansiRed  = "\x1b[31m"
+	ansiBold = "\x1b[1m"
+)
+
+// errorPosition matches the position reported by the InfluxQL parser, e.g.
+// `error parsing query: found FORM, expected FROM at line 1, char 10`
+var errorPosition = regexp.MustCompile(`at line (\d+), char (\d+)`)
+
+// positionError is a syntax error of the statement at the 1-based line and char
+type positionError struct {
+	err       error
+	statement string
+	line      int
+	char      int
+}
+
+func (e *positionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *positionError) Unwrap() error {
+	return e.err
+}
+
+// withPosition attaches the statement to the error if its message reports a position in it
+func withPosition(err error, statement string) error {
+	if err == nil {
+		return nil
+	}
+	match := errorPosition.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+	line, _ := strconv.Atoi(match[1])
+	char, _ := strconv.Atoi(match[2])
+	if line < 1 || line > strings.Count(statement, "\n")+1 || char < 1 {
+		return err
+	}
+	return &positionError{err: err, statement: statement, line: line, char: char}
+}
+
+// validateStatement parses the statement with the bound params on the client by the parser
+// of openGemini, so that the syntax errors are reported without a round trip to the server.
+// The position of the error is found by the InfluxQL parser, the openGemini one reports none.
+func validateStatement(statement string, params map[string]any) error {
+	parser := influxql.NewParser(strings.NewReader(statement))
+	defer parser.Release()
+	parser.SetParams(params)
+	yyParser := influxql.NewYyParser(parser.GetScanner(), parser.GetPara())
+	yyParser.ParseTokens()
+	_, err := yyParser.GetQuery()
+	if err == nil {
+		return nil
+	}
+
+	positioned := influxql.NewParser(strings.NewReader(statement))
+	defer positioned.Release()
+	positioned.SetParams(params)
+	if _, perr := positioned.ParseQuery(); perr != nil && errorPosition.MatchString(perr.Error()) {
+		err = perr
+	}
+	return withPosition(fmt.Errorf("error parsing query: %w", err), statement)
+}
+
+// writeError writes the error, and the statement with a caret under the position of
+// a syntax error. The message is colored when w is a terminal.
+func writeError(w io.Writer, prefix string, err error, color bool) {
+	message := prefix + err.Error()
+	if color {
+		message = ansiBold + ansiRed + message + ansiReset
+	}
+	_, _ = fmt.Fprintln(w, message)
+
+	var pe *positionError
+	if !errors.As(err, &pe) {
+		return
+	}
+	lines := strings.Split(pe.statement, "\n")
+	for i := range pe.line {
+		_, _ = fmt.Fprintf(w, "  %s\n", lines[i])
+	}
+	// tabs are kept so that the caret lines up with the statement on the terminal
+	var indent strings.Builder
+	for i, r := range []rune(lines[pe.line-1]) {
+		if i >= pe.char-1 {
+			break
+		}
+		if r == '\t' {
+			indent.WriteRune('\t')
+		} else {
+			indent.WriteRune(' ')
+		}
+	}
+	caret := "^"
+	if color {
+		caret = ansiBold + ansiRed + caret + ansiReset
+	}
+	_, _ = fmt.Fprintf(w, "  %s%s\n", indent.String(), caret)
+}
+
+// printError writes the error to the file, colored if it is a terminal and NO_COLOR is not set
+func printError(f *os.File, prefix string, err error) {
+	writeError(f, prefix, err, term.IsTerminal(int(f.Fd())) && os.Getenv("NO_COLOR") == "")
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteError(t *testing.T) {
+	err := withPosition(errors.New("error parsing query: found FORM, expected FROM at line 1, char 10"), "SELECT * FORM cpu")
+	var buf strings.Builder
+	writeError(&buf, "error: ", err, false)
+	require.Equal(t, "error: error parsing query: found FORM, expected FROM at line 1, char 10\n"+
+		"  SELECT * FORM cpu\n"+
+		"           ^\n", buf.String())
+
+	err = withPosition(errors.New("found BY, expected FROM at line 2, char 3"), "SELECT *\n\tBY")
+	buf.Reset()
+	writeError(&buf, "", err, false)
+	require.Equal(t, "found BY, expected FROM at line 2, char 3\n  SELECT *\n  \tBY\n  \t ^\n", buf.String())
+
+	// the position is out of the statement
+	err = withPosition(errors.New("found EOF at line 3, char 1"), "SELECT *")
+	buf.Reset()
+	writeError(&buf, "error: ", err, false)
+	require.Equal(t, "error: found EOF at line 3, char 1\n", buf.String())
+	require.NoError(t, withPosition(nil, "SELECT *"))
+}
+
+func TestValidateStatement(t *testing.T) {
+	require.NoError(t, validateStatement("SELECT mean(value) FROM cpu WHERE time > now() - 1h GROUP BY time(1m)", nil))
+	require.NoError(t, validateStatement("SELECT * FROM cpu WHERE host = $host", map[string]any{"host": "server01"}))
+	require.ErrorContains(t, validateStatement("SELECT * FROM cpu WHERE host = $host", nil), "missing parameter: host")
+	// the statements only openGemini supports
+	require.NoError(t, validateStatement("SHOW CLUSTER", nil))
+	require.NoError(t, validateStatement("CREATE MEASUREMENT cpu (host TAG, usage FLOAT64 FIELD) WITH ENGINETYPE = columnstore", nil))
+	require.ErrorContains(t, validateStatement("SELECT * FROM", nil), "error parsing query")
+
+	err := validateStatement("SELECT * FORM cpu", nil)
+	var pe *positionError
+	require.ErrorAs(t, err, &pe)
+	require.Equal(t, 1, pe.line)
+	require.Equal(t, 10, pe.char)
+}
+
+func TestCommandLine_ValidateBeforeSending(t *testing.T) {
+	cl, queries := newTestCommandLine(t, &CommandLineConfig{})
+	require.NoError(t, cl.execute("set validate=on"))
+	require.ErrorContains(t, cl.execute("SELECT * FORM cpu"), "expected FROM")
+	require.Empty(t, *queries)
+
+	require.NoError(t, cl.execute("SELECT * FROM cpu"))
+	require.Len(t, *queries, 1)
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+
+	"github.com/openGemini/openGemini-cli/geminiql"
+	"github.com/openGemini/openGemini-cli/prompt"
+)
+
+// savedQueries loads ~/.ts-cli/queries.toml the first time the saved queries are used
+func (cl *CommandLine) savedQueries() (*prompt.SavedQueries, error) {
+	if cl.queries != nil {
+		return cl.queries, nil
+	}
+	path, err := prompt.DefaultSavedQueriesPath()
+	if err != nil {
+		return nil, err
+	}
+	queries := prompt.NewSavedQueries(path)
+	if err = queries.Load(); err != nil {
+		return nil, err
+	}
+	cl.queries = queries
+	return queries, nil
+}
+
+func (cl *CommandLine) executeSaveQuery(stmt *geminiql.SaveQueryStatement) error {
+	if !variableName.MatchString(stmt.Name) {
+		return fmt.Errorf("invalid query name %q, it must only contain letters, digits and underscores", stmt.Name)
+	}
+	statement := stmt.Statement
+	if statement == "" {
+		statement = cl.lastStatement
+	}
+	if statement == "" {
+		return errors.New("no statement has been executed yet")
+	}
+	if strings.HasPrefix(statement, `\`) {
+		return errors.New("backslash commands can't be saved")
+	}
+	queries, err := cl.savedQueries()
+	if err != nil {
+		return err
+	}
+	if err = queries.Save(stmt.Name, statement); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %s: %s\n", stmt.Name, statement)
+	return nil
+}
+
+func (cl *CommandLine) executeRunQuery(stmt *geminiql.RunQueryStatement) error {
+	queries, err := cl.savedQueries()
+	if err != nil {
+		return err
+	}
+	statement, ok := queries.Get(stmt.Name)
+	if !ok {
+		return fmt.Errorf("saved query %s not found", stmt.Name)
+	}
+	if len(stmt.Variables) != 0 {
+		variables := make(map[string]string)
+		maps.Copy(variables, cl.variables)
+		for _, kv := range stmt.Variables {
+			variables[fmt.Sprintf("%v", kv.First())] = fmt.Sprintf("%v", kv.Second())
+		}
+		defer func(variables map[string]string) { cl.variables = variables }(cl.variables)
+		cl.variables = variables
+	}
+	return cl.executeStatement(statement)
+}
+
+func (cl *CommandLine) executeListQueries(stmt *geminiql.ListQueriesStatement) error {
+	queries, err := cl.savedQueries()
+	if err != nil {
+		return err
+	}
+	var series = &opengemini.Series{Columns: []string{"name", "query"}}
+	for _, name := range queries.Names() {
+		statement, _ := queries.Get(name)
+		series.Values = append(series.Values, opengemini.SeriesValue{name, statement})
+	}
+	cl.output(&opengemini.SeriesResult{Series: []*opengemini.Series{series}})
+	return nil
+}
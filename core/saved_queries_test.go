@@ -0,0 +1,54 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/openGemini-cli/prompt"
+)
+
+func TestCommandLine_SavedQueries(t *testing.T) {
+	cl, queries := newTestCommandLine(t, &CommandLineConfig{Database: "db0"})
+	cl.queries = prompt.NewSavedQueries(filepath.Join(t.TempDir(), "queries.toml"))
+
+	require.ErrorContains(t, cl.execute(`\save slow`), "no statement has been executed yet")
+	require.NoError(t, cl.execute("SHOW QUERIES"))
+	require.NoError(t, cl.execute(`\save slow`))
+	require.NoError(t, cl.execute(`\save load SELECT load FROM cpu WHERE host = :'host'`))
+	require.ErrorContains(t, cl.execute(`\save loop \run slow`), "backslash commands can't be saved")
+	require.NoError(t, cl.execute(`\list`))
+
+	require.NoError(t, cl.execute(`\set host server01`))
+	require.NoError(t, cl.execute(`\run slow`))
+	require.NoError(t, cl.execute(`\run load`))
+	require.NoError(t, cl.execute(`\run load host=server02`))
+	require.Equal(t, "server01", cl.variables["host"])
+	require.ErrorContains(t, cl.execute(`\run missing`), "saved query missing not found")
+
+	var commands []string
+	for _, query := range *queries {
+		commands = append(commands, query.command)
+	}
+	require.Equal(t, []string{
+		"SHOW QUERIES",
+		"SHOW QUERIES",
+		"SELECT load FROM cpu WHERE host = 'server01'",
+		"SELECT load FROM cpu WHERE host = 'server02'",
+	}, commands)
+}
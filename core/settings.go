@@ -0,0 +1,312 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openGemini/opengemini-client-go/opengemini"
+
+	"github.com/openGemini/openGemini-cli/prompt"
+)
+
+// setting is a session option of the command line which can be changed by `SET <name>=<value>`
+// and is listed by `SHOW SETTINGS`
+type setting struct {
+	name        string
+	title       string // used by the confirmation message, e.g. "Timer is enabled"
+	description string
+	get         func(cl *CommandLine) string
+	set         func(cl *CommandLine, value string) error
+}
+
+var settings = []*setting{
+	{
+		name:        "timeout",
+		title:       "Timeout",
+		description: "request timeout in milliseconds",
+		get:         func(cl *CommandLine) string { return strconv.Itoa(cl.Timeout) },
+		set: func(cl *CommandLine, value string) error {
+			timeout, err := strconv.Atoi(value)
+			if err != nil || timeout <= 0 {
+				return fmt.Errorf("invalid timeout %q, it must be a positive number of milliseconds", value)
+			}
+			cl.Timeout = timeout
+			cl.httpClient.SetTimeout(time.Duration(timeout) * time.Millisecond)
+			return nil
+		},
+	},
+	{
+		name:        "precision",
+		title:       "Precision",
+		description: "format of the timestamp: rfc3339, h, m, s, ms, u or ns",
+		get: func(cl *CommandLine) string {
+			if cl.Precision == "" {
+				return "ns"
+			}
+			return cl.Precision
+		},
+		set: func(cl *CommandLine, value string) error {
+			precision := strings.ToLower(value)
+			switch precision {
+			case "":
+				cl.Precision = "ns"
+			case "h", "m", "s", "ms", "u", "ns", "rfc3339":
+				cl.Precision = precision
+			default:
+				return fmt.Errorf("unknown precision %q. precision must be rfc3339, h, m, s, ms, u or ns", precision)
+			}
+			return nil
+		},
+	},
+	{
+		name:        "database",
+		title:       "Database",
+		description: "database of the queries",
+		get:         func(cl *CommandLine) string { return cl.Database },
+		set: func(cl *CommandLine, value string) error {
+			cl.Database = value
+			return nil
+		},
+	},
+	{
+		name:        "retention_policy",
+		title:       "Retention policy",
+		description: "default retention policy of the queries and inserts, empty uses the database default",
+		get:         func(cl *CommandLine) string { return cl.RetentionPolicy },
+		set: func(cl *CommandLine, value string) error {
+			cl.RetentionPolicy = value
+			return nil
+		},
+	},
+	{
+		name:        "format",
+		title:       "Format",
+		description: "output format: table, column, csv, tsv, markdown, json or pretty-json",
+		get:         func(cl *CommandLine) string { return string(cl.format) },
+		set: func(cl *CommandLine, value string) error {
+			format, err := ParseOutputFormat(value)
+			if err != nil {
+				return err
+			}
+			cl.format = format
+			return nil
+		},
+	},
+	boolSetting("vertical", "Vertical", "print query output rows vertically",
+		func(cl *CommandLine) *bool { return &cl.DisplayVertical }),
+	{
+		name:        "pager",
+		title:       "Pager",
+		description: "command paging the output longer than the terminal, on uses $PAGER or less -S",
+		get: func(cl *CommandLine) string {
+			if cl.pager == "" {
+				return "off"
+			}
+			return cl.pager
+		},
+		set: func(cl *CommandLine, value string) error {
+			cl.pager = pagerCommand(value)
+			return nil
+		},
+	},
+	{
+		name:        "prompt_template",
+		title:       "Prompt template",
+		description: "prompt with the placeholders {user}, {host}, {port}, {db}, {rp} and {profile}",
+		get:         func(cl *CommandLine) string { return cl.promptTemplate },
+		set: func(cl *CommandLine, value string) error {
+			if value == "" {
+				value = DefaultPromptTemplate
+			}
+			cl.promptTemplate = value
+			return nil
+		},
+	},
+	{
+		name:        "theme",
+		title:       "Theme",
+		description: "colors of the syntax highlighting at the prompt: dark, light or off",
+		get:         func(cl *CommandLine) string { return cl.theme },
+		set: func(cl *CommandLine, value string) error {
+			if err := prompt.ValidateTheme(value); err != nil {
+				return err
+			}
+			cl.theme = strings.ToLower(value)
+			if cl.prompt != nil {
+				return cl.prompt.SetTheme(cl.theme)
+			}
+			return nil
+		},
+	},
+	boolSetting("timer", "Timer", "display execution time",
+		func(cl *CommandLine) *bool { return &cl.timer }),
+	{
+		name:        "debug",
+		title:       "Debug",
+		description: "display http request interaction content",
+		get:         func(cl *CommandLine) string { return formatBool(cl.debug) },
+		set: func(cl *CommandLine, value string) error {
+			debug, err := parseBool(value)
+			if err != nil {
+				return err
+			}
+			cl.debug = debug
+			cl.httpClient.SetDebug(cl.debug)
+			return nil
+		},
+	},
+	{
+		name:        "prompt",
+		title:       "Prompt",
+		description: "command line reminder and suggestion",
+		get:         func(cl *CommandLine) string { return formatBool(cl.suggest) },
+		set: func(cl *CommandLine, value string) error {
+			suggest, err := parseBool(value)
+			if err != nil {
+				return err
+			}
+			if cl.prompt == nil {
+				return errors.New("prompt is only available in the interactive shell")
+			}
+			cl.suggest = suggest
+			cl.prompt.SwitchCompleter(cl.suggest)
+			return nil
+		},
+	},
+	boolSetting("chunked", "Chunked", "stream query results in chunks as they arrive",
+		func(cl *CommandLine) *bool { return &cl.chunked }),
+	boolSetting("kill_on_cancel", "Kill on cancel", "also kill the query on the server when it is canceled by ctrl-c",
+		func(cl *CommandLine) *bool { return &cl.killOnCancel }),
+	boolSetting("validate", "Validate", "check the syntax of the statements on the client before sending them to the server",
+		func(cl *CommandLine) *bool { return &cl.validate }),
+	{
+		name:        "chunk_size",
+		title:       "Chunk size",
+		description: "number of points per chunk in chunked mode, 0 uses the server default",
+		get:         func(cl *CommandLine) string { return strconv.Itoa(cl.chunkSize) },
+		set: func(cl *CommandLine, value string) error {
+			size, err := strconv.Atoi(value)
+			if err != nil || size < 0 {
+				return fmt.Errorf("invalid chunk_size %q, it must be a non-negative integer", value)
+			}
+			cl.chunkSize = size
+			return nil
+		},
+	},
+	{
+		name:        "float_precision",
+		title:       "Float precision",
+		description: "digits after the decimal point of float values, full keeps the precision of the value",
+		get: func(cl *CommandLine) string {
+			if cl.valueFormat.floatPrecision < 0 {
+				return "full"
+			}
+			return strconv.Itoa(cl.valueFormat.floatPrecision)
+		},
+		set: func(cl *CommandLine, value string) error {
+			if strings.EqualFold(value, "full") {
+				cl.valueFormat.floatPrecision = -1
+				return nil
+			}
+			precision, err := strconv.Atoi(value)
+			if err != nil || precision < -1 {
+				return fmt.Errorf("invalid float_precision %q, it must be full, -1 or a non-negative integer", value)
+			}
+			cl.valueFormat.floatPrecision = precision
+			return nil
+		},
+	},
+	{
+		name:        "null_display",
+		title:       "Null display",
+		description: "string displayed for null values",
+		get:         func(cl *CommandLine) string { return cl.valueFormat.nullDisplay },
+		set: func(cl *CommandLine, value string) error {
+			cl.valueFormat.nullDisplay = value
+			return nil
+		},
+	},
+}
+
+func boolSetting(name, title, description string, field func(cl *CommandLine) *bool) *setting {
+	return &setting{
+		name:        name,
+		title:       title,
+		description: description,
+		get:         func(cl *CommandLine) string { return formatBool(*field(cl)) },
+		set: func(cl *CommandLine, value string) error {
+			b, err := parseBool(value)
+			if err != nil {
+				return err
+			}
+			*field(cl) = b
+			return nil
+		},
+	}
+}
+
+func lookupSetting(name string) (*setting, error) {
+	name = strings.ToLower(name)
+	for _, s := range settings {
+		if s.name == name {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown setting %q, use `show settings` to list all settings", name)
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "on", "true", "1", "enable", "enabled":
+		return true, nil
+	case "off", "false", "0", "disable", "disabled":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid boolean value %q, it must be on or off", value)
+	}
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// applySetting validates and changes the session setting, then confirms the new value
+func (cl *CommandLine) applySetting(name, value string) error {
+	s, err := lookupSetting(name)
+	if err != nil {
+		return err
+	}
+	if err = s.set(cl, value); err != nil {
+		return err
+	}
+	fmt.Printf("%s is %s\n", s.title, s.get(cl))
+	return nil
+}
+
+func (cl *CommandLine) showSettings() {
+	var series = &opengemini.Series{Columns: []string{"name", "value", "description"}}
+	for _, s := range settings {
+		series.Values = append(series.Values, opengemini.SeriesValue{s.name, s.get(cl), s.description})
+	}
+	cl.output(&opengemini.SeriesResult{Series: []*opengemini.Series{series}})
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandLine_ApplySetting(t *testing.T) {
+	cl, _ := newTestCommandLine(t, &CommandLineConfig{})
+
+	require.NoError(t, cl.execute("set timer=on, vertical=true, format=csv"))
+	require.True(t, cl.timer)
+	require.True(t, cl.DisplayVertical)
+	require.Equal(t, OutputFormatCSV, cl.format)
+
+	require.NoError(t, cl.execute("set timeout=2000"))
+	require.Equal(t, 2000, cl.Timeout)
+	require.Equal(t, 2*time.Second, cl.httpClient.(*HttpClientCreator).client.Timeout)
+
+	require.NoError(t, cl.execute("set float_precision=2, null_display='(null)'"))
+	require.Equal(t, valueFormat{floatPrecision: 2, nullDisplay: "(null)"}, cl.valueFormat)
+	require.NoError(t, cl.execute("set float_precision=full"))
+	require.Equal(t, -1, cl.valueFormat.floatPrecision)
+
+	require.NoError(t, cl.execute("set retention_policy=rp0, chunk_size=100"))
+	require.Equal(t, "rp0", cl.RetentionPolicy)
+	require.Equal(t, 100, cl.chunkSize)
+}
+
+func TestCommandLine_ApplySettingInvalid(t *testing.T) {
+	cl, queries := newTestCommandLine(t, &CommandLineConfig{})
+
+	require.ErrorContains(t, cl.execute("set timer=maybe"), `invalid boolean value "maybe"`)
+	require.ErrorContains(t, cl.execute("set timeout=0"), `invalid timeout "0"`)
+	require.ErrorContains(t, cl.execute("set precision=us"), `unknown precision "us"`)
+	require.ErrorContains(t, cl.execute("set format=xml"), `unknown format "xml"`)
+	require.ErrorContains(t, cl.execute("set chunk_size=-1"), `invalid chunk_size "-1"`)
+	require.ErrorContains(t, cl.execute("set color=on"), `unknown setting "color"`)
+	require.ErrorContains(t, cl.execute("set prompt=on"), "only available in the interactive shell")
+	require.Empty(t, *queries)
+}
+
+func TestCommandLine_ToggleStatements(t *testing.T) {
+	cl, _ := newTestCommandLine(t, &CommandLineConfig{})
+
+	require.NoError(t, cl.execute("timer"))
+	require.True(t, cl.timer)
+	require.NoError(t, cl.execute("timer"))
+	require.False(t, cl.timer)
+
+	require.NoError(t, cl.execute("debug"))
+	require.True(t, cl.debug)
+	require.True(t, cl.httpClient.(*HttpClientCreator).debug)
+
+	require.NoError(t, cl.execute("chunk_size 10"))
+	require.Equal(t, 10, cl.chunkSize)
+
+	require.NoError(t, cl.execute("precision rfc3339"))
+	require.Equal(t, "rfc3339", cl.Precision)
+
+	require.NoError(t, cl.execute("show settings"))
+}
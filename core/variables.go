@@ -0,0 +1,180 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/openGemini/openGemini/lib/util/lifted/influx/influxql"
+
+	"github.com/openGemini/openGemini-cli/geminiql"
+)
+
+var variableName = regexp.MustCompile(`^[\pL\pN_]+$`)
+
+func (cl *CommandLine) executeSetVariable(stmt *geminiql.SetVariableStatement) error {
+	if stmt.Name == "" {
+		for _, name := range slices.Sorted(maps.Keys(cl.variables)) {
+			fmt.Printf("%s = %s\n", name, geminiql.QuoteString(cl.variables[name]))
+		}
+		return nil
+	}
+	if !variableName.MatchString(stmt.Name) {
+		return fmt.Errorf("invalid variable name %q, it must only contain letters, digits and underscores", stmt.Name)
+	}
+	if cl.variables == nil {
+		cl.variables = make(map[string]string)
+	}
+	cl.variables[stmt.Name] = stmt.Value
+	return nil
+}
+
+func (cl *CommandLine) executeUnsetVariable(stmt *geminiql.UnsetVariableStatement) error {
+	if _, ok := cl.variables[stmt.Name]; !ok {
+		return errors.New("variable " + stmt.Name + " is not set")
+	}
+	delete(cl.variables, stmt.Name)
+	return nil
+}
+
+// interpolate substitutes the variables referenced in the statement outside of the quoted strings,
+// identifiers and regex literals, like psql does:
+//
+//	:name    the value as it is
+//	:'name'  the value quoted as a string literal
+//	:"name"  the value quoted as an identifier
+//
+// The variables referenced by `$name` are returned as the params bound by the server instead.
+// References to unknown variables and `::` type casts are kept unchanged.
+func interpolate(statement string, variables map[string]string) (string, map[string]any) {
+	var params map[string]any
+	var buf strings.Builder
+	var runes = []rune(statement)
+	var quote rune
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' && i+1 < len(runes) {
+				buf.WriteRune(ch)
+				i++
+				ch = runes[i]
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"' || ch == '/' && startsRegex(runes, i):
+			quote = ch
+		case ch == ':' && i+1 < len(runes) && runes[i+1] == ':':
+			buf.WriteString("::")
+			i++
+			continue
+		case ch == ':':
+			if value, n, ok := variableReference(runes[i+1:], variables); ok {
+				buf.WriteString(value)
+				i += n
+				continue
+			}
+		case ch == '$':
+			name := variableNameAt(runes[i+1:])
+			if value, ok := variables[name]; ok {
+				if params == nil {
+					params = make(map[string]any)
+				}
+				params[name] = paramValue(value)
+			}
+		}
+		buf.WriteRune(ch)
+	}
+	return buf.String(), params
+}
+
+// startsRegex reports whether the `/` at runes[i] opens a regex literal instead of dividing, like the
+// influxql scanner a division follows an identifier, a number or `)`
+func startsRegex(runes []rune, i int) bool {
+	end := i
+	for end > 0 && unicode.IsSpace(runes[end-1]) {
+		end--
+	}
+	if end == 0 {
+		return true
+	}
+	switch previous := runes[end-1]; {
+	case previous == ')' || previous == '"':
+		return false
+	case previous == '_' || unicode.IsLetter(previous) || unicode.IsDigit(previous):
+		start := end
+		for start > 0 && (runes[start-1] == '_' || unicode.IsLetter(runes[start-1]) || unicode.IsDigit(runes[start-1])) {
+			start--
+		}
+		// a keyword like FROM or AND is followed by a regex
+		return influxql.Lookup(string(runes[start:end])) != influxql.IDENT
+	}
+	return true
+}
+
+// variableReference resolves the reference following a `:`, it returns the substituted text and
+// the number of runes consumed
+func variableReference(runes []rune, variables map[string]string) (string, int, bool) {
+	if len(runes) > 0 && (runes[0] == '\'' || runes[0] == '"') {
+		name := variableNameAt(runes[1:])
+		if name == "" || len(runes) < len(name)+2 || runes[len(name)+1] != runes[0] {
+			return "", 0, false
+		}
+		value, ok := variables[name]
+		if !ok {
+			return "", 0, false
+		}
+		if runes[0] == '\'' {
+			return geminiql.QuoteString(value), len(name) + 2, true
+		}
+		return geminiql.DoubleQuote(value), len(name) + 2, true
+	}
+	name := variableNameAt(runes)
+	value, ok := variables[name]
+	return value, len(name), ok && name != ""
+}
+
+func variableNameAt(runes []rune) string {
+	n := slices.IndexFunc(runes, func(r rune) bool { return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	if n < 0 {
+		n = len(runes)
+	}
+	return string(runes[:n])
+}
+
+// paramValue types the value of a bound parameter, so `LIMIT $n` gets a number
+func paramValue(value string) any {
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	default:
+		return value
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolate(t *testing.T) {
+	variables := map[string]string{
+		"host":  "server01",
+		"mst":   "cpu load",
+		"since": "now() - 1h",
+		"limit": "10",
+		"plain": "cpu",
+		"kw":    "from",
+	}
+	tests := []struct {
+		name      string
+		statement string
+		expect    string
+		params    map[string]any
+	}{
+		{
+			name:      "raw value",
+			statement: "SELECT * FROM cpu WHERE time > :since",
+			expect:    "SELECT * FROM cpu WHERE time > now() - 1h",
+		},
+		{
+			name:      "quoted string and identifier",
+			statement: `SELECT * FROM :"mst" WHERE host = :'host'`,
+			expect:    `SELECT * FROM "cpu load" WHERE host = 'server01'`,
+		},
+		{
+			name:      "identifier is always quoted",
+			statement: `SELECT :"kw" FROM :"plain"`,
+			expect:    `SELECT "from" FROM "cpu"`,
+		},
+		{
+			name:      "quoted text is kept",
+			statement: `SELECT * FROM cpu WHERE host = ':host' AND "a:host" = 'it\'s :host'`,
+			expect:    `SELECT * FROM cpu WHERE host = ':host' AND "a:host" = 'it\'s :host'`,
+		},
+		{
+			name:      "regex is kept",
+			statement: `SELECT * FROM /a:host/ WHERE host =~ /a:b\/:host/ AND value > :limit / 2`,
+			expect:    `SELECT * FROM /a:host/ WHERE host =~ /a:b\/:host/ AND value > 10 / 2`,
+		},
+		{
+			name:      "type cast and unknown variable are kept",
+			statement: "SELECT value::float FROM cpu WHERE time > :unknown",
+			expect:    "SELECT value::float FROM cpu WHERE time > :unknown",
+		},
+		{
+			name:      "bound params",
+			statement: "SELECT * FROM cpu WHERE host = $host LIMIT $limit",
+			expect:    "SELECT * FROM cpu WHERE host = $host LIMIT $limit",
+			params:    map[string]any{"host": "server01", "limit": int64(10)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, params := interpolate(tt.statement, variables)
+			require.Equal(t, tt.expect, statement)
+			require.Equal(t, tt.params, params)
+		})
+	}
+}
+
+func TestCommandLine_Variables(t *testing.T) {
+	cl, queries := newTestCommandLine(t, &CommandLineConfig{Database: "db0"})
+
+	require.NoError(t, cl.execute(`\set host 'server 01'`))
+	require.NoError(t, cl.execute(`\set n 5`))
+	require.NoError(t, cl.execute(`\set`))
+	require.NoError(t, cl.execute(`SELECT * FROM cpu WHERE host = :'host' LIMIT $n`))
+	require.Equal(t, []recordedQuery{{
+		database: "db0",
+		command:  `SELECT * FROM cpu WHERE host = 'server 01' LIMIT $n`,
+		params:   `{"n":5}`,
+	}}, *queries)
+
+	require.NoError(t, cl.execute(`\unset host`))
+	require.ErrorContains(t, cl.execute(`\unset host`), "variable host is not set")
+	require.ErrorContains(t, cl.execute(`\set bad-name 1`), "invalid variable name")
+}
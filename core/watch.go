@@ -0,0 +1,153 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+
+	"github.com/openGemini/openGemini-cli/geminiql"
+)
+
+const (
+	ansiReverse = "\x1b[7m"
+	ansiReset   = "\x1b[0m"
+)
+
+func (cl *CommandLine) executeWatch(stmt *geminiql.WatchStatement) error {
+	interval := time.Duration(stmt.Seconds * float64(time.Second))
+	if interval <= 0 {
+		return fmt.Errorf("invalid interval %v, it must be a positive number of seconds", stmt.Seconds)
+	}
+	// Ctrl-C stops watching and returns to the prompt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	cl.watch(ctx, os.Stdout, interval, stmt.Statement, term.IsTerminal(int(os.Stdout.Fd())))
+	return nil
+}
+
+// watch runs the statement every interval until ctx is done. On a terminal every run redraws the
+// previous one in place and the cells changed since then are highlighted, otherwise the runs
+// are written one after another.
+func (cl *CommandLine) watch(ctx context.Context, w io.Writer, interval time.Duration, statement string, terminal bool) {
+	var previous string
+	var lines int
+	for {
+		var result bytes.Buffer
+		cl.captured = &result
+		err := cl.executeOnRemote(statement)
+		cl.captured = nil
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(&result, "error: %s\n", err)
+		}
+
+		header := fmt.Sprintf("Every %v: %s    %s\n\n", interval, statement, time.Now().Format(time.DateTime))
+		current := result.String()
+		if terminal {
+			if lines > 0 {
+				// move up to the first line of the previous run and clear the screen below
+				_, _ = fmt.Fprintf(w, "\x1b[%dA\x1b[J", lines)
+			}
+			_, _ = io.WriteString(w, header+highlightChanges(previous, current))
+			lines = screenLines(header + current)
+		} else {
+			_, _ = io.WriteString(w, header+current)
+		}
+		previous = current
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// screenLines counts the lines the text takes on the terminal, including the wrapped ones
+func screenLines(text string) int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if err != nil || width <= 0 {
+		return len(lines)
+	}
+	var n int
+	for _, line := range lines {
+		n += max(1, (runewidth.StringWidth(line)+width-1)/width)
+	}
+	return n
+}
+
+// highlightChanges highlights the cells of current which differ from the same cells of previous,
+// the cells are the words of a line between the spaces and the column separators of the formats
+func highlightChanges(previous, current string) string {
+	if previous == "" {
+		return current
+	}
+	var buf strings.Builder
+	previousLines := strings.Split(previous, "\n")
+	for i, line := range strings.Split(current, "\n") {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		var previousCells []string
+		if i < len(previousLines) {
+			previousCells = splitCells(previousLines[i])
+		}
+		for j, cell := range splitCells(line) {
+			if isCellSeparator(cell) || j < len(previousCells) && previousCells[j] == cell {
+				buf.WriteString(cell)
+				continue
+			}
+			buf.WriteString(ansiReverse + cell + ansiReset)
+		}
+	}
+	return buf.String()
+}
+
+// splitCells splits the line into alternating runs of separators and cell text
+func splitCells(line string) []string {
+	var cells []string
+	var start int
+	for i, r := range line {
+		if i > start && isSeparatorRune(r) != isSeparatorRune(rune(line[start])) {
+			cells = append(cells, line[start:i])
+			start = i
+		}
+	}
+	if start < len(line) {
+		cells = append(cells, line[start:])
+	}
+	return cells
+}
+
+func isCellSeparator(cell string) bool {
+	return cell != "" && isSeparatorRune(rune(cell[0]))
+}
+
+func isSeparatorRune(r rune) bool {
+	return r == ' ' || r == '|' || r == ',' || r == '\t' || r == '+' || r == '-'
+}
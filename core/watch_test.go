@@ -0,0 +1,71 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/openGemini/openGemini-cli/geminiql"
+)
+
+func TestHighlightChanges(t *testing.T) {
+	previous := "+-------+\n| count |\n+-------+\n| 10    |\n+-------+\n"
+	current := "+-------+\n| count |\n+-------+\n| 12    |\n+-------+\n"
+	require.Equal(t, current, highlightChanges("", current))
+	require.Equal(t, current, highlightChanges(current, current))
+	require.Equal(t, strings.Replace(current, "12", ansiReverse+"12"+ansiReset, 1), highlightChanges(previous, current))
+}
+
+func TestCommandLine_Watch(t *testing.T) {
+	var runs atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runs.Add(1)
+		_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["count"],"values":[[10]]}]}]}`))
+	}))
+	defer server.Close()
+	cl := &CommandLine{
+		CommandLineConfig: &CommandLineConfig{Timeout: 1000},
+		parser:            geminiql.QLNewParser(),
+		httpClient:        newTestHttpClient(server),
+		format:            OutputFormatCSV,
+		valueFormat:       valueFormat{floatPrecision: -1},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		cl.watch(ctx, &out, 10*time.Millisecond, "SELECT count(*) FROM cpu", false)
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+
+	require.Nil(t, cl.captured)
+	require.Contains(t, out.String(), "Every 10ms: SELECT count(*) FROM cpu")
+	require.Contains(t, out.String(), "cpu,,10\n")
+
+	require.ErrorContains(t, cl.execute("watch 0 SHOW QUERIES"), "invalid interval")
+}
@@ -108,3 +108,91 @@ func (s *PromptStatement) stmt() {}
 type VerticalStatement struct{}
 
 func (s *VerticalStatement) stmt() {}
+
+type FormatStatement struct {
+	Format string
+}
+
+func (s *FormatStatement) stmt() {}
+
+type ShowSettingsStatement struct{}
+
+func (s *ShowSettingsStatement) stmt() {}
+
+type HistoryStatement struct {
+	Limit int64
+}
+
+func (s *HistoryStatement) stmt() {}
+
+type PagerStatement struct {
+	Pager string
+}
+
+func (s *PagerStatement) stmt() {}
+
+// OutputStatement redirects the query output to the file by `\o`, or copies it to the file
+// by `\tee`, an empty file resets the output to the terminal
+type OutputStatement struct {
+	File   string
+	Append bool
+	Tee    bool
+}
+
+func (s *OutputStatement) stmt() {}
+
+// SetVariableStatement sets the client-side variable by `\set name value`, no name lists the variables
+type SetVariableStatement struct {
+	Name  string
+	Value string
+}
+
+func (s *SetVariableStatement) stmt() {}
+
+type UnsetVariableStatement struct {
+	Name string
+}
+
+func (s *UnsetVariableStatement) stmt() {}
+
+// SaveQueryStatement saves the statement under the name by `\save name [statement]`, no statement
+// saves the last executed one
+type SaveQueryStatement struct {
+	Name      string
+	Statement string
+}
+
+func (s *SaveQueryStatement) stmt() {}
+
+// RunQueryStatement runs the saved query by `\run name [key=value, ...]`, the variables override
+// the ones set by `\set` while the query runs
+type RunQueryStatement struct {
+	Name      string
+	Variables Pairs
+}
+
+func (s *RunQueryStatement) stmt() {}
+
+type ListQueriesStatement struct{}
+
+func (s *ListQueriesStatement) stmt() {}
+
+// WatchStatement runs the statement on the server every Seconds seconds
+type WatchStatement struct {
+	Seconds   float64
+	Statement string
+}
+
+func (s *WatchStatement) stmt() {}
+
+// ConnectStatement switches the shell to another server by `connect <host>[:port] [--ssl] [--user u]`,
+// the arguments are parsed like the flags of ts-cli
+type ConnectStatement struct {
+	Args []string
+}
+
+func (s *ConnectStatement) stmt() {}
+
+type StatusStatement struct{}
+
+func (s *StatusStatement) stmt() {}
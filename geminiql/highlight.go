@@ -0,0 +1,208 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geminiql
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/openGemini/openGemini/lib/util/lifted/influx/influxql"
+)
+
+// TokenKind classifies the text of a statement for syntax highlighting
+type TokenKind int
+
+const (
+	// TokenText is whitespace, identifiers, operators and anything else not highlighted
+	TokenText TokenKind = iota
+	TokenKeyword
+	TokenFunction
+	TokenString
+	TokenNumber
+	TokenDuration
+	TokenRegex
+)
+
+// Token is a piece of the highlighted statement
+type Token struct {
+	Kind TokenKind
+	Text string
+}
+
+// shellKeywords are the keywords of the shell commands taken from the tokens of the parser, the
+// keywords of InfluxQL are known to the influxql scanner
+var shellKeywords = func() map[string]bool {
+	keywords := make(map[string]bool)
+	// skip $end, error and $unk, and the tokens which are not keywords like IDENT or BACKSLASH_O
+	for _, name := range QLToknames[3:] {
+		switch {
+		case strings.HasPrefix(name, "BACKSLASH_"), strings.HasPrefix(name, "REDIRECT_"):
+		case slices.Contains([]string{"DOT", "COMMA", "EQ", "IDENT", "INTEGER", "DECIMAL", "STRING", "RAW"}, name):
+		default:
+			keywords[name] = true
+		}
+	}
+	return keywords
+}()
+
+// scanned is a token of the influxql scanner and the offset of its first rune in the input
+type scanned struct {
+	tok    influxql.Token
+	offset int
+}
+
+// Tokenize splits the statement into tokens for syntax highlighting, concatenating the text of the
+// tokens returns the input. It never fails, the incomplete input typed at the prompt is highlighted
+// as far as it goes. An identifier followed by `(` is a function if it is one of functions, which
+// are upper case names.
+func Tokenize(input string, functions map[string]bool) []Token {
+	runes := []rune(input)
+	scannedTokens := scanTokens(input, runes)
+	var tokens []Token
+	for i, token := range scannedTokens {
+		end := len(runes)
+		if i+1 < len(scannedTokens) {
+			end = scannedTokens[i+1].offset
+		}
+		text := string(runes[token.offset:end])
+		tokens = appendToken(tokens, Token{Kind: tokenKind(scannedTokens, i, text, functions), Text: text})
+	}
+	return tokens
+}
+
+// scanTokens runs the influxql scanner over the input, the text of a token runs from its offset
+// to the offset of the next one since the scanner drops the quotes and escapes of the literals
+func scanTokens(input string, runes []rune) []scanned {
+	lines := lineOffsets(runes)
+	scanner := influxql.NewScanner(strings.NewReader(input))
+	var tokens []scanned
+	for {
+		tok, pos, _ := scanner.Scan()
+		if tok == influxql.EOF || pos.Line >= len(lines) {
+			return tokens
+		}
+		offset := min(lines[pos.Line]+pos.Char, len(runes))
+		switch tok {
+		case influxql.STRING, influxql.BADSTRING:
+			// the position of a single quoted string is the rune before the quote
+			if offset+1 < len(runes) && runes[offset] != '\'' && runes[offset+1] == '\'' {
+				offset++
+			}
+		case influxql.BADESCAPE:
+			// the position of an invalid escape is inside the string, the scanner loses track of
+			// the string after it so the rest of the input is left to the string
+			for i := offset; i > 0 && (len(tokens) == 0 || i > tokens[len(tokens)-1].offset+1); i-- {
+				if runes[i-1] == '\'' || runes[i-1] == '"' {
+					offset = i - 1
+					break
+				}
+			}
+		}
+
+		n := len(tokens)
+		switch {
+		case n == 0 && offset > 0:
+			tokens = append(tokens, scanned{tok: influxql.WS})
+		case n > 0 && offset <= tokens[n-1].offset:
+			continue
+		case n > 0 && tokens[n-1].tok == influxql.ILLEGAL && runes[tokens[n-1].offset] == '\\' &&
+			offset == tokens[n-1].offset+1:
+			// the name of a meta command like \set belongs to the backslash
+			continue
+		}
+		tokens = append(tokens, scanned{tok: tok, offset: offset})
+		if tok == influxql.BADESCAPE {
+			return tokens
+		}
+	}
+}
+
+// lineOffsets returns the offsets of the lines in the input, the scanner reads `\r\n` and `\r` as
+// a line feed
+func lineOffsets(runes []rune) []int {
+	offsets := []int{0}
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\r' && i+1 < len(runes) && runes[i+1] == '\n' {
+			i++
+		}
+		if runes[i] == '\r' || runes[i] == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+func tokenKind(tokens []scanned, i int, text string, functions map[string]bool) TokenKind {
+	switch tokens[i].tok {
+	case influxql.STRING, influxql.BADSTRING, influxql.BADESCAPE:
+		if strings.HasPrefix(text, "'") {
+			return TokenString
+		}
+		// a double quoted identifier
+		return TokenText
+	case influxql.INTEGER, influxql.NUMBER:
+		return TokenNumber
+	case influxql.DURATIONVAL:
+		// a number running into letters like 3cpu is scanned as a duration
+		if _, err := influxql.ParseDuration(text); err == nil {
+			return TokenDuration
+		}
+		return TokenText
+	case influxql.REGEX, influxql.BADREGEX:
+		return TokenRegex
+	case influxql.ILLEGAL:
+		if _, ok := metaCommands[text]; ok {
+			return TokenKeyword
+		}
+		// the regex is not closed yet
+		if strings.HasPrefix(text, "/") {
+			return TokenRegex
+		}
+		return TokenText
+	case influxql.IDENT:
+		name := strings.ToUpper(text)
+		switch {
+		case functions[name] && nextToken(tokens, i) == influxql.LPAREN:
+			return TokenFunction
+		case shellKeywords[name]:
+			return TokenKeyword
+		}
+		return TokenText
+	}
+	// the scanner returns the keywords of InfluxQL as their own tokens
+	if influxql.Lookup(text) != influxql.IDENT {
+		return TokenKeyword
+	}
+	return TokenText
+}
+
+// nextToken returns the token following tokens[i] which is not whitespace
+func nextToken(tokens []scanned, i int) influxql.Token {
+	for _, token := range tokens[i+1:] {
+		if token.tok != influxql.WS {
+			return token.tok
+		}
+	}
+	return influxql.EOF
+}
+
+// appendToken merges the adjacent plain text to keep the number of tokens low
+func appendToken(tokens []Token, token Token) []Token {
+	if n := len(tokens); n > 0 && token.Kind == TokenText && tokens[n-1].Kind == TokenText {
+		tokens[n-1].Text += token.Text
+		return tokens
+	}
+	return append(tokens, token)
+}
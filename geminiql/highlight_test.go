@@ -0,0 +1,102 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geminiql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	functions := map[string]bool{"MEAN": true, "COUNT": true}
+	input := `SELECT mean(value) / 2, count FROM cpu WHERE host =~ /^server\/0/ AND region = 'us\'west' AND time > now() - 1h30m GROUP BY time(5m) LIMIT 10.5`
+	tokens := Tokenize(input, functions)
+
+	var text strings.Builder
+	var highlighted []Token
+	for _, token := range tokens {
+		text.WriteString(token.Text)
+		if token.Kind != TokenText {
+			highlighted = append(highlighted, token)
+		}
+	}
+	require.Equal(t, input, text.String())
+	require.Equal(t, []Token{
+		{Kind: TokenKeyword, Text: "SELECT"},
+		{Kind: TokenFunction, Text: "mean"},
+		{Kind: TokenNumber, Text: "2"},
+		{Kind: TokenKeyword, Text: "FROM"},
+		{Kind: TokenKeyword, Text: "WHERE"},
+		{Kind: TokenRegex, Text: `/^server\/0/`},
+		{Kind: TokenKeyword, Text: "AND"},
+		{Kind: TokenString, Text: `'us\'west'`},
+		{Kind: TokenKeyword, Text: "AND"},
+		{Kind: TokenDuration, Text: "1h30m"},
+		{Kind: TokenKeyword, Text: "GROUP"},
+		{Kind: TokenKeyword, Text: "BY"},
+		{Kind: TokenDuration, Text: "5m"},
+		{Kind: TokenKeyword, Text: "LIMIT"},
+		{Kind: TokenNumber, Text: "10.5"},
+	}, highlighted)
+}
+
+func TestTokenizeIncomplete(t *testing.T) {
+	require.Equal(t, []Token{
+		{Kind: TokenKeyword, Text: "SELECT"},
+		{Kind: TokenText, Text: " * "},
+		{Kind: TokenKeyword, Text: "FROM"},
+		{Kind: TokenText, Text: " 3cpu "},
+		{Kind: TokenKeyword, Text: "WHERE"},
+		{Kind: TokenText, Text: " a="},
+		{Kind: TokenString, Text: "'unterminated"},
+	}, Tokenize("SELECT * FROM 3cpu WHERE a='unterminated", nil))
+
+	require.Equal(t, []Token{
+		{Kind: TokenKeyword, Text: `\set`},
+		{Kind: TokenText, Text: " n "},
+		{Kind: TokenNumber, Text: "5"},
+		{Kind: TokenText, Text: "; "},
+		{Kind: TokenKeyword, Text: "timer"},
+	}, Tokenize(`\set n 5; timer`, nil))
+}
+
+func TestTokenizeMultiLine(t *testing.T) {
+	require.Equal(t, []Token{
+		{Kind: TokenKeyword, Text: "SELECT"},
+		{Kind: TokenText, Text: ` "from", `},
+		{Kind: TokenString, Text: `'a'`},
+		{Kind: TokenText, Text: " "},
+		{Kind: TokenString, Text: `'b'`},
+		{Kind: TokenText, Text: "\r\n"},
+		{Kind: TokenKeyword, Text: "FROM"},
+		{Kind: TokenText, Text: " m\n"},
+		{Kind: TokenKeyword, Text: "WHERE"},
+		{Kind: TokenText, Text: " x =~"},
+	}, Tokenize("SELECT \"from\", 'a' 'b'\r\nFROM m\nWHERE x =~", nil))
+}
+
+func TestTokenizeBadInput(t *testing.T) {
+	require.Equal(t, []Token{
+		{Kind: TokenText, Text: "x = "},
+		{Kind: TokenString, Text: `'a\qb' AND y = 1`},
+	}, Tokenize(`x = 'a\qb' AND y = 1`, nil))
+
+	require.Equal(t, []Token{
+		{Kind: TokenText, Text: "x =~ "},
+		{Kind: TokenRegex, Text: "/^unterminated"},
+	}, Tokenize("x =~ /^unterminated", nil))
+}
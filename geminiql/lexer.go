@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
@@ -105,6 +106,20 @@ func (t *Tokenizer) Scan() (tok int, val string) {
 	if t.firstToken() == INSERT && t.lastToken() == EQ {
 		return t.scanRaw()
 	}
+	if len(t.tokens) > 1 {
+		switch t.firstToken() {
+		case BACKSLASH_O, BACKSLASH_TEE:
+			return t.scanFileName()
+		case BACKSLASH_SET, BACKSLASH_UNSET, BACKSLASH_SAVE:
+			return t.scanVariable()
+		case WATCH:
+			if len(t.tokens) > 2 {
+				return t.scanWatchedStatement()
+			}
+		case CONNECT:
+			return t.scanArgument()
+		}
+	}
 
 	ch := t.Lookahead()
 
@@ -121,6 +136,8 @@ func (t *Tokenizer) Scan() (tok int, val string) {
 		return EOF_TOKEN, ""
 	case '\'', '"':
 		return t.scanString()
+	case '\\':
+		return t.scanMetaCommand()
 	case '.':
 		ch = t.read()
 		return DOT, string(ch)
@@ -246,6 +263,116 @@ func (t *Tokenizer) scanString() (int, string) {
 	}
 }
 
+// metaCommands are the backslash commands of the shell, `\q` is handled before parsing
+var metaCommands = map[string]int{
+	`\o`:       BACKSLASH_O,
+	`\tee`:     BACKSLASH_TEE,
+	`\set`:     BACKSLASH_SET,
+	`\unset`:   BACKSLASH_UNSET,
+	`\save`:    BACKSLASH_SAVE,
+	`\run`:     BACKSLASH_RUN,
+	`\list`:    BACKSLASH_LIST,
+	`\connect`: CONNECT,
+}
+
+func (t *Tokenizer) scanMetaCommand() (int, string) {
+	var buf bytes.Buffer
+	buf.WriteRune(t.read())
+	for isLetter(t.Lookahead()) {
+		buf.WriteRune(t.read())
+	}
+	if tok, ok := metaCommands[buf.String()]; ok {
+		return tok, buf.String()
+	}
+	return ILLEGAL_TOKEN, buf.String()
+}
+
+// scanFileName scans the argument of `\o` and `\tee`, which is the rest of the input optionally
+// quoted, a leading `>>` appends to the file instead of truncating it
+func (t *Tokenizer) scanFileName() (int, string) {
+	ch := t.Lookahead()
+	switch {
+	case ch == EOF:
+		return EOF_TOKEN, ""
+	case unicode.IsSpace(ch):
+		return t.scanWhiteSpace()
+	case ch == '\'' || ch == '"':
+		return t.scanString()
+	}
+
+	var buf bytes.Buffer
+	if ch == '>' {
+		buf.WriteRune(t.read())
+		if t.Lookahead() == '>' {
+			t.read()
+			return REDIRECT_APPEND, ">>"
+		}
+	}
+	return t.scanRest(&buf)
+}
+
+// scanVariable scans the arguments of `\set`, `\unset` and `\save`, the name of the variable or the
+// saved query is the first word and the value is the rest of the input optionally quoted
+func (t *Tokenizer) scanVariable() (int, string) {
+	ch := t.Lookahead()
+	switch {
+	case ch == EOF:
+		return EOF_TOKEN, ""
+	case unicode.IsSpace(ch):
+		return t.scanWhiteSpace()
+	case !slices.Contains(t.tokens, IDENT):
+		var buf bytes.Buffer
+		for ch = t.read(); ch != EOF && !unicode.IsSpace(ch); ch = t.read() {
+			buf.WriteRune(ch)
+		}
+		_ = t.unRead()
+		return IDENT, buf.String()
+	case ch == '\'' || ch == '"':
+		return t.scanString()
+	}
+	return t.scanRest(&bytes.Buffer{})
+}
+
+// scanWatchedStatement scans the statement following the interval of `watch`
+func (t *Tokenizer) scanWatchedStatement() (int, string) {
+	ch := t.Lookahead()
+	switch {
+	case ch == EOF:
+		return EOF_TOKEN, ""
+	case unicode.IsSpace(ch):
+		return t.scanWhiteSpace()
+	}
+	return t.scanRest(&bytes.Buffer{})
+}
+
+// scanArgument scans the arguments of `connect` separated by whitespace, an argument is
+// optionally quoted
+func (t *Tokenizer) scanArgument() (int, string) {
+	ch := t.Lookahead()
+	switch {
+	case ch == EOF:
+		return EOF_TOKEN, ""
+	case unicode.IsSpace(ch):
+		return t.scanWhiteSpace()
+	case ch == '\'' || ch == '"':
+		return t.scanString()
+	}
+	var buf bytes.Buffer
+	for ch = t.read(); ch != EOF && !unicode.IsSpace(ch); ch = t.read() {
+		buf.WriteRune(ch)
+	}
+	_ = t.unRead()
+	return STRING, buf.String()
+}
+
+// scanRest appends the rest of the input to buf, the trailing spaces are dropped
+func (t *Tokenizer) scanRest(buf *bytes.Buffer) (int, string) {
+	for ch := t.read(); ch != EOF; ch = t.read() {
+		buf.WriteRune(ch)
+	}
+	return STRING, strings.TrimRightFunc(buf.String(), unicode.IsSpace)
+}
+
 func (t *Tokenizer) scanDigit() (int, string) {
 	var buf bytes.Buffer
 
@@ -57,14 +57,30 @@ const TIMER = 57355
 const DEBUG = 57356
 const PROMPT = 57357
 const VERTICAL = 57358
-const DOT = 57359
-const COMMA = 57360
-const EQ = 57361
-const IDENT = 57362
-const INTEGER = 57363
-const DECIMAL = 57364
-const STRING = 57365
-const RAW = 57366
+const FORMAT = 57359
+const SHOW = 57360
+const SETTINGS = 57361
+const HISTORY = 57362
+const PAGER = 57363
+const WATCH = 57364
+const CONNECT = 57365
+const STATUS = 57366
+const BACKSLASH_O = 57367
+const BACKSLASH_TEE = 57368
+const BACKSLASH_SET = 57369
+const BACKSLASH_UNSET = 57370
+const BACKSLASH_SAVE = 57371
+const BACKSLASH_RUN = 57372
+const BACKSLASH_LIST = 57373
+const REDIRECT_APPEND = 57374
+const DOT = 57375
+const COMMA = 57376
+const EQ = 57377
+const IDENT = 57378
+const INTEGER = 57379
+const DECIMAL = 57380
+const STRING = 57381
+const RAW = 57382
 
 var QLToknames = [...]string{
 	"$end",
@@ -83,6 +99,22 @@ var QLToknames = [...]string{
 	"DEBUG",
 	"PROMPT",
 	"VERTICAL",
+	"FORMAT",
+	"SHOW",
+	"SETTINGS",
+	"HISTORY",
+	"PAGER",
+	"WATCH",
+	"CONNECT",
+	"STATUS",
+	"BACKSLASH_O",
+	"BACKSLASH_TEE",
+	"BACKSLASH_SET",
+	"BACKSLASH_UNSET",
+	"BACKSLASH_SAVE",
+	"BACKSLASH_RUN",
+	"BACKSLASH_LIST",
+	"REDIRECT_APPEND",
 	"DOT",
 	"COMMA",
 	"EQ",
@@ -99,7 +131,7 @@ const QLEofCode = 1
 const QLErrCode = 2
 const QLInitialStackSize = 16
 
-//line parser.y:322
+//line parser.y:598
 
 //line yacctab:1
 var QLExca = [...]int8{
@@ -110,68 +142,103 @@ var QLExca = [...]int8{
 
 const QLPrivate = 57344
 
-const QLLast = 63
+const QLLast = 122
 
 var QLAct = [...]int8{
-	43, 33, 27, 31, 14, 61, 15, 16, 17, 18,
-	19, 20, 21, 22, 23, 24, 25, 55, 57, 58,
-	56, 42, 41, 45, 26, 37, 45, 35, 32, 30,
-	39, 38, 52, 48, 51, 47, 34, 46, 36, 30,
-	40, 44, 49, 50, 29, 28, 13, 12, 11, 54,
-	53, 59, 60, 10, 9, 8, 7, 6, 5, 4,
-	3, 2, 1,
+	94, 57, 51, 55, 24, 88, 25, 26, 27, 28,
+	29, 30, 31, 32, 33, 34, 35, 36, 37, 120,
+	38, 39, 47, 48, 49, 40, 41, 42, 43, 44,
+	45, 46, 67, 68, 89, 81, 61, 62, 63, 64,
+	65, 66, 80, 79, 106, 69, 114, 116, 117, 115,
+	78, 105, 76, 103, 90, 77, 102, 101, 100, 92,
+	60, 50, 86, 87, 93, 75, 96, 111, 71, 96,
+	56, 54, 85, 84, 83, 82, 73, 72, 99, 110,
+	98, 97, 74, 58, 70, 59, 91, 104, 95, 53,
+	52, 23, 54, 108, 109, 107, 22, 21, 20, 19,
+	113, 112, 18, 17, 16, 15, 14, 13, 12, 11,
+	118, 119, 10, 9, 8, 7, 6, 5, 4, 3,
+	2, 1,
 }
 
 var QLPact = [...]int16{
 	0, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, 19, 8, 7, -1000, 4, -1000,
-	-1000, 11, -1000, -1000, -1000, -1000, 8, -1000, 1, 3,
-	-1000, -1000, 20, -1000, 17, 14, -1000, -1000, -1000, 9,
-	-1000, -1000, 6, -1000, 16, 13, 8, 7, -3, -1000,
-	6, 6, -19, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, 56, 34, 24, -1000, 31, -1000,
+	-1000, 41, -1000, -1000, -1000, -1000, 40, 63, 28, 16,
+	11, 3, 39, 38, 37, 36, -1000, 25, -5, -1000,
+	34, -1000, 22, 30, -1000, -1000, 48, -1000, 46, 43,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 19,
+	-1000, 18, 17, -1000, 14, 24, 12, 5, -1000, -5,
+	35, -1000, -1000, 33, -1000, 45, 32, 34, 24, 10,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 33,
+	33, -21, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000,
 }
 
 var QLPgo = [...]int8{
-	0, 62, 61, 60, 59, 58, 57, 56, 55, 54,
-	53, 48, 47, 46, 2, 45, 44, 41, 0, 40,
-	38, 3, 36, 1,
+	0, 121, 120, 119, 118, 117, 116, 115, 114, 113,
+	112, 109, 108, 107, 106, 105, 104, 103, 102, 99,
+	98, 97, 96, 91, 2, 90, 89, 88, 0, 86,
+	85, 84, 3, 5, 83, 1,
 }
 
 var QLR1 = [...]int8{
 	0, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 4, 3, 2, 2, 5, 6, 20,
-	7, 8, 9, 10, 11, 12, 13, 21, 21, 14,
-	14, 15, 15, 22, 22, 22, 22, 23, 23, 18,
-	18, 17, 16, 19,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 4, 3, 2, 2, 5, 6, 31,
+	7, 8, 9, 10, 11, 12, 13, 14, 14, 15,
+	16, 16, 17, 17, 17, 18, 18, 18, 18, 18,
+	18, 19, 19, 19, 19, 20, 20, 20, 20, 20,
+	21, 21, 22, 33, 33, 23, 32, 32, 24, 24,
+	25, 25, 34, 34, 34, 34, 35, 35, 30, 30,
+	30, 30, 30, 30, 30, 30, 30, 30, 28, 28,
+	27, 26, 29,
 }
 
 var QLR2 = [...]int8{
 	0, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 2, 2, 4, 2, 1, 2, 1,
-	1, 1, 2, 1, 1, 1, 1, 1, 3, 1,
-	2, 4, 2, 3, 3, 3, 3, 1, 3, 1,
-	3, 3, 1, 1,
+	1, 1, 2, 1, 1, 1, 1, 1, 2, 2,
+	1, 2, 1, 2, 2, 1, 2, 3, 1, 2,
+	3, 1, 2, 3, 2, 2, 3, 2, 3, 1,
+	3, 3, 2, 1, 2, 1, 1, 3, 1, 2,
+	4, 2, 3, 3, 3, 3, 1, 3, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 3,
+	3, 1, 1,
 }
 
 var QLChk = [...]int16{
 	-1000, -1, -2, -3, -4, -5, -6, -7, -8, -9,
-	-10, -11, -12, -13, 4, 6, 7, 8, 9, 10,
-	11, 12, 13, 14, 15, 16, 5, -14, -15, -16,
-	20, -21, 20, -23, -22, 20, -20, 21, 20, -21,
-	-19, 21, 18, -18, -17, 20, 17, 18, 19, -14,
-	-18, 18, 19, -21, -23, 20, 23, 21, 22, -18,
-	-18, 24,
+	-10, -11, -12, -13, -14, -15, -16, -17, -18, -19,
+	-20, -21, -22, -23, 4, 6, 7, 8, 9, 10,
+	11, 12, 13, 14, 15, 16, 17, 18, 20, 21,
+	25, 26, 27, 28, 29, 30, 31, 22, 23, 24,
+	5, -24, -25, -26, 36, -32, 36, -35, -34, -30,
+	36, 12, 13, 14, 15, 16, 17, 8, 9, 21,
+	-31, 37, 36, 36, 19, 37, 36, 39, 39, 32,
+	39, 32, 36, 36, 36, 36, 37, 38, -33, 39,
+	-32, -29, 37, 34, -28, -27, 36, 33, 34, 35,
+	39, 39, 39, 39, -35, 39, 39, -33, -24, -28,
+	34, 35, -32, -35, 36, 39, 37, 38, -28, -28,
+	40,
 }
 
 var QLDef = [...]int8{
 	0, -2, 1, 2, 3, 4, 5, 6, 7, 8,
-	9, 10, 11, 12, 0, 0, 0, 17, 0, 20,
-	21, 0, 23, 24, 25, 26, 0, 16, 29, 0,
-	42, 14, 27, 13, 37, 0, 18, 19, 22, 0,
-	30, 43, 0, 32, 39, 0, 0, 0, 0, 15,
-	0, 0, 0, 28, 38, 33, 34, 35, 36, 31,
-	40, 41,
+	9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
+	19, 20, 21, 22, 0, 0, 0, 27, 0, 30,
+	31, 0, 33, 34, 35, 36, 37, 0, 40, 42,
+	45, 48, 51, 0, 0, 0, 59, 0, 0, 65,
+	0, 26, 68, 0, 91, 24, 66, 23, 76, 0,
+	78, 79, 80, 81, 82, 83, 84, 85, 86, 87,
+	28, 29, 32, 38, 39, 41, 43, 44, 46, 0,
+	49, 0, 52, 54, 55, 57, 0, 0, 62, 63,
+	0, 69, 92, 0, 71, 88, 0, 0, 0, 0,
+	47, 50, 53, 56, 58, 60, 61, 64, 25, 0,
+	0, 0, 67, 77, 72, 73, 74, 75, 70, 89,
+	90,
 }
 
 var QLTok1 = [...]int8{
@@ -181,7 +248,8 @@ var QLTok1 = [...]int8{
 var QLTok2 = [...]int8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
-	22, 23, 24,
+	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
+	32, 33, 34, 35, 36, 37, 38, 39, 40,
 }
 
 var QLTok3 = [...]int8{
@@ -200,7 +268,7 @@ var QLErrorMessages = [...]struct {
 
 var (
 	QLDebug        = 0
-	QLErrorVerbose = true
+	QLErrorVerbose = false
 )
 
 type QLLexer interface {
@@ -527,87 +595,147 @@ QLdefault:
 
 	case 1:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:67
+//line parser.y:68
 		{
 			updateStmt(QLlex, QLDollar[1].stmt)
 		}
 	case 2:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:71
+//line parser.y:72
 		{
 			updateStmt(QLlex, QLDollar[1].stmt)
 		}
 	case 3:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:75
+//line parser.y:76
 		{
 			updateStmt(QLlex, QLDollar[1].stmt)
 		}
 	case 4:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:79
+//line parser.y:80
 		{
 			updateStmt(QLlex, QLDollar[1].stmt)
 		}
 	case 5:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:83
+//line parser.y:84
 		{
 			updateStmt(QLlex, QLDollar[1].stmt)
 		}
 	case 6:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:87
+//line parser.y:88
 		{
 			updateStmt(QLlex, QLDollar[1].stmt)
 		}
 	case 7:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:91
+//line parser.y:92
 		{
 			updateStmt(QLlex, QLDollar[1].stmt)
 		}
 	case 8:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:95
+//line parser.y:96
 		{
 			updateStmt(QLlex, QLDollar[1].stmt)
 		}
 	case 9:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:99
+//line parser.y:100
 		{
 			updateStmt(QLlex, QLDollar[1].stmt)
 		}
 	case 10:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:103
+//line parser.y:104
 		{
 			updateStmt(QLlex, QLDollar[1].stmt)
 		}
 	case 11:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:107
+//line parser.y:108
 		{
 			updateStmt(QLlex, QLDollar[1].stmt)
 		}
 	case 12:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:111
+//line parser.y:112
 		{
 			updateStmt(QLlex, QLDollar[1].stmt)
 		}
 	case 13:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:116
+		{
+			updateStmt(QLlex, QLDollar[1].stmt)
+		}
+	case 14:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:120
+		{
+			updateStmt(QLlex, QLDollar[1].stmt)
+		}
+	case 15:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:124
+		{
+			updateStmt(QLlex, QLDollar[1].stmt)
+		}
+	case 16:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:128
+		{
+			updateStmt(QLlex, QLDollar[1].stmt)
+		}
+	case 17:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:132
+		{
+			updateStmt(QLlex, QLDollar[1].stmt)
+		}
+	case 18:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:136
+		{
+			updateStmt(QLlex, QLDollar[1].stmt)
+		}
+	case 19:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:140
+		{
+			updateStmt(QLlex, QLDollar[1].stmt)
+		}
+	case 20:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:144
+		{
+			updateStmt(QLlex, QLDollar[1].stmt)
+		}
+	case 21:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:148
+		{
+			updateStmt(QLlex, QLDollar[1].stmt)
+		}
+	case 22:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:152
+		{
+			updateStmt(QLlex, QLDollar[1].stmt)
+		}
+	case 23:
 		QLDollar = QLS[QLpt-2 : QLpt+1]
-//line parser.y:117
+//line parser.y:158
 		{
 			stmt := &SetStatement{}
 			stmt.KVS = QLDollar[2].pairs
 			QLVAL.stmt = stmt
 		}
-	case 14:
+	case 24:
 		QLDollar = QLS[QLpt-2 : QLpt+1]
-//line parser.y:125
+//line parser.y:166
 		{
 			stmt := &UseStatement{}
 			if len(QLDollar[2].strslice) == 1 {
@@ -621,9 +749,9 @@ QLdefault:
 				QLlex.Error("namespace must be <db>.<rp>")
 			}
 		}
-	case 15:
+	case 25:
 		QLDollar = QLS[QLpt-4 : QLpt+1]
-//line parser.y:141
+//line parser.y:182
 		{
 			stmt := &InsertStatement{}
 			stmt.LineProtocol = QLDollar[4].str
@@ -636,189 +764,478 @@ QLdefault:
 				QLVAL.stmt = stmt
 			}
 		}
-	case 16:
+	case 26:
 		QLDollar = QLS[QLpt-2 : QLpt+1]
-//line parser.y:154
+//line parser.y:195
 		{
 			stmt := &InsertStatement{}
 			stmt.LineProtocol = QLDollar[2].str
 			QLVAL.stmt = stmt
 		}
-	case 17:
+	case 27:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:162
+//line parser.y:203
 		{
 			stmt := &ChunkedStatement{}
 			QLVAL.stmt = stmt
 		}
-	case 18:
+	case 28:
 		QLDollar = QLS[QLpt-2 : QLpt+1]
-//line parser.y:169
+//line parser.y:210
 		{
 			stmt := &ChunkSizeStatement{}
 			stmt.Size = QLDollar[2].integer
 			QLVAL.stmt = stmt
 		}
-	case 19:
+	case 29:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:177
+//line parser.y:218
 		{
 			QLVAL.integer = QLDollar[1].integer
 		}
-	case 20:
+	case 30:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:183
+//line parser.y:224
 		{
 			stmt := &AuthStatement{}
 			QLVAL.stmt = stmt
 		}
-	case 21:
+	case 31:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:190
+//line parser.y:231
 		{
 			stmt := &HelpStatement{}
 			QLVAL.stmt = stmt
 		}
-	case 22:
+	case 32:
 		QLDollar = QLS[QLpt-2 : QLpt+1]
-//line parser.y:197
+//line parser.y:238
 		{
 			stmt := &PrecisionStatement{}
 			stmt.Precision = QLDollar[2].str
 			QLVAL.stmt = stmt
 		}
-	case 23:
+	case 33:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:205
+//line parser.y:246
 		{
 			stmt := &TimerStatement{}
 			QLVAL.stmt = stmt
 		}
-	case 24:
+	case 34:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:212
+//line parser.y:253
 		{
 			stmt := &DebugStatement{}
 			QLVAL.stmt = stmt
 		}
-	case 25:
+	case 35:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:219
+//line parser.y:260
 		{
 			stmt := &PromptStatement{}
 			QLVAL.stmt = stmt
 		}
-	case 26:
+	case 36:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:226
+//line parser.y:267
 		{
 			stmt := &VerticalStatement{}
 			QLVAL.stmt = stmt
 		}
-	case 27:
+	case 37:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:274
+		{
+			stmt := &FormatStatement{}
+			QLVAL.stmt = stmt
+		}
+	case 38:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:279
+		{
+			stmt := &FormatStatement{}
+			stmt.Format = QLDollar[2].str
+			QLVAL.stmt = stmt
+		}
+	case 39:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:287
+		{
+			stmt := &ShowSettingsStatement{}
+			QLVAL.stmt = stmt
+		}
+	case 40:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:294
+		{
+			stmt := &HistoryStatement{}
+			QLVAL.stmt = stmt
+		}
+	case 41:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:299
+		{
+			stmt := &HistoryStatement{}
+			stmt.Limit = QLDollar[2].integer
+			QLVAL.stmt = stmt
+		}
+	case 42:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:307
+		{
+			stmt := &PagerStatement{}
+			QLVAL.stmt = stmt
+		}
+	case 43:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:312
+		{
+			stmt := &PagerStatement{}
+			stmt.Pager = QLDollar[2].str
+			QLVAL.stmt = stmt
+		}
+	case 44:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:318
+		{
+			stmt := &PagerStatement{}
+			stmt.Pager = QLDollar[2].str
+			QLVAL.stmt = stmt
+		}
+	case 45:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:326
+		{
+			stmt := &OutputStatement{}
+			QLVAL.stmt = stmt
+		}
+	case 46:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:331
+		{
+			stmt := &OutputStatement{}
+			stmt.File = QLDollar[2].str
+			QLVAL.stmt = stmt
+		}
+	case 47:
+		QLDollar = QLS[QLpt-3 : QLpt+1]
+//line parser.y:337
+		{
+			stmt := &OutputStatement{}
+			stmt.File = QLDollar[3].str
+			stmt.Append = true
+			QLVAL.stmt = stmt
+		}
+	case 48:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:233
+//line parser.y:344
+		{
+			stmt := &OutputStatement{}
+			stmt.Tee = true
+			QLVAL.stmt = stmt
+		}
+	case 49:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:350
+		{
+			stmt := &OutputStatement{}
+			stmt.File = QLDollar[2].str
+			stmt.Tee = true
+			QLVAL.stmt = stmt
+		}
+	case 50:
+		QLDollar = QLS[QLpt-3 : QLpt+1]
+//line parser.y:357
+		{
+			stmt := &OutputStatement{}
+			stmt.File = QLDollar[3].str
+			stmt.Append = true
+			stmt.Tee = true
+			QLVAL.stmt = stmt
+		}
+	case 51:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:367
+		{
+			stmt := &SetVariableStatement{}
+			QLVAL.stmt = stmt
+		}
+	case 52:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:372
+		{
+			stmt := &SetVariableStatement{}
+			stmt.Name = QLDollar[2].str
+			QLVAL.stmt = stmt
+		}
+	case 53:
+		QLDollar = QLS[QLpt-3 : QLpt+1]
+//line parser.y:378
+		{
+			stmt := &SetVariableStatement{}
+			stmt.Name = QLDollar[2].str
+			stmt.Value = QLDollar[3].str
+			QLVAL.stmt = stmt
+		}
+	case 54:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:385
+		{
+			stmt := &UnsetVariableStatement{}
+			stmt.Name = QLDollar[2].str
+			QLVAL.stmt = stmt
+		}
+	case 55:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:393
+		{
+			stmt := &SaveQueryStatement{}
+			stmt.Name = QLDollar[2].str
+			QLVAL.stmt = stmt
+		}
+	case 56:
+		QLDollar = QLS[QLpt-3 : QLpt+1]
+//line parser.y:399
+		{
+			stmt := &SaveQueryStatement{}
+			stmt.Name = QLDollar[2].str
+			stmt.Statement = QLDollar[3].str
+			QLVAL.stmt = stmt
+		}
+	case 57:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:406
+		{
+			stmt := &RunQueryStatement{}
+			stmt.Name = QLDollar[2].str
+			QLVAL.stmt = stmt
+		}
+	case 58:
+		QLDollar = QLS[QLpt-3 : QLpt+1]
+//line parser.y:412
+		{
+			stmt := &RunQueryStatement{}
+			stmt.Name = QLDollar[2].str
+			stmt.Variables = QLDollar[3].pairs
+			QLVAL.stmt = stmt
+		}
+	case 59:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:419
+		{
+			stmt := &ListQueriesStatement{}
+			QLVAL.stmt = stmt
+		}
+	case 60:
+		QLDollar = QLS[QLpt-3 : QLpt+1]
+//line parser.y:426
+		{
+			stmt := &WatchStatement{}
+			stmt.Seconds = float64(QLDollar[2].integer)
+			stmt.Statement = QLDollar[3].str
+			QLVAL.stmt = stmt
+		}
+	case 61:
+		QLDollar = QLS[QLpt-3 : QLpt+1]
+//line parser.y:433
+		{
+			stmt := &WatchStatement{}
+			stmt.Seconds = QLDollar[2].decimal
+			stmt.Statement = QLDollar[3].str
+			QLVAL.stmt = stmt
+		}
+	case 62:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:442
+		{
+			stmt := &ConnectStatement{}
+			stmt.Args = QLDollar[2].strslice
+			QLVAL.stmt = stmt
+		}
+	case 63:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:450
 		{
 			QLVAL.strslice = []string{QLDollar[1].str}
 		}
-	case 28:
+	case 64:
+		QLDollar = QLS[QLpt-2 : QLpt+1]
+//line parser.y:454
+		{
+			QLVAL.strslice = append([]string{QLDollar[1].str}, QLDollar[2].strslice...)
+		}
+	case 65:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:460
+		{
+			stmt := &StatusStatement{}
+			QLVAL.stmt = stmt
+		}
+	case 66:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:467
+		{
+			QLVAL.strslice = []string{QLDollar[1].str}
+		}
+	case 67:
 		QLDollar = QLS[QLpt-3 : QLpt+1]
-//line parser.y:237
+//line parser.y:471
 		{
 			ns := []string{QLDollar[1].str}
 			QLVAL.strslice = append(ns, QLDollar[3].strslice...)
 		}
-	case 29:
+	case 68:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:244
+//line parser.y:478
 		{
 			QLVAL.str = QLDollar[1].str
 		}
-	case 30:
+	case 69:
 		QLDollar = QLS[QLpt-2 : QLpt+1]
-//line parser.y:248
+//line parser.y:482
 		{
 			QLVAL.str = QLDollar[1].str + " " + QLDollar[2].str
 		}
-	case 31:
+	case 70:
 		QLDollar = QLS[QLpt-4 : QLpt+1]
-//line parser.y:254
+//line parser.y:488
 		{
 			QLVAL.str = QLDollar[1].str + QLDollar[2].str + QLDollar[3].str + " " + QLDollar[4].str
 		}
-	case 32:
+	case 71:
 		QLDollar = QLS[QLpt-2 : QLpt+1]
-//line parser.y:258
+//line parser.y:492
 		{
 			QLVAL.str = QLDollar[1].str + " " + QLDollar[2].str
 		}
-	case 33:
+	case 72:
 		QLDollar = QLS[QLpt-3 : QLpt+1]
-//line parser.y:264
+//line parser.y:498
 		{
 			p := NewPair(QLDollar[1].str, QLDollar[3].str)
 			QLVAL.pair = *p
 		}
-	case 34:
+	case 73:
 		QLDollar = QLS[QLpt-3 : QLpt+1]
-//line parser.y:269
+//line parser.y:503
 		{
 			p := NewPair(QLDollar[1].str, QLDollar[3].str)
 			QLVAL.pair = *p
 		}
-	case 35:
+	case 74:
 		QLDollar = QLS[QLpt-3 : QLpt+1]
-//line parser.y:274
+//line parser.y:508
 		{
 			p := NewPair(QLDollar[1].str, QLDollar[3].integer)
 			QLVAL.pair = *p
 		}
-	case 36:
+	case 75:
 		QLDollar = QLS[QLpt-3 : QLpt+1]
-//line parser.y:279
+//line parser.y:513
 		{
 			p := NewPair(QLDollar[1].str, QLDollar[3].decimal)
 			QLVAL.pair = *p
 		}
-	case 37:
+	case 76:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:286
+//line parser.y:520
 		{
 			QLVAL.pairs = Pairs{QLDollar[1].pair}
 		}
-	case 38:
+	case 77:
 		QLDollar = QLS[QLpt-3 : QLpt+1]
-//line parser.y:290
+//line parser.y:524
 		{
-			QLVAL.pairs = append(QLDollar[3].pairs, QLDollar[1].pair)
+			QLVAL.pairs = append(Pairs{QLDollar[1].pair}, QLDollar[3].pairs...)
 		}
-	case 39:
+	case 78:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:296
+//line parser.y:530
 		{
 			QLVAL.str = QLDollar[1].str
 		}
-	case 40:
+	case 79:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:534
+		{
+			QLVAL.str = QLDollar[1].str
+		}
+	case 80:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:538
+		{
+			QLVAL.str = QLDollar[1].str
+		}
+	case 81:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:542
+		{
+			QLVAL.str = QLDollar[1].str
+		}
+	case 82:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:546
+		{
+			QLVAL.str = QLDollar[1].str
+		}
+	case 83:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:550
+		{
+			QLVAL.str = QLDollar[1].str
+		}
+	case 84:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:554
+		{
+			QLVAL.str = QLDollar[1].str
+		}
+	case 85:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:558
+		{
+			QLVAL.str = QLDollar[1].str
+		}
+	case 86:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:562
+		{
+			QLVAL.str = QLDollar[1].str
+		}
+	case 87:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:566
+		{
+			QLVAL.str = QLDollar[1].str
+		}
+	case 88:
+		QLDollar = QLS[QLpt-1 : QLpt+1]
+//line parser.y:572
+		{
+			QLVAL.str = QLDollar[1].str
+		}
+	case 89:
 		QLDollar = QLS[QLpt-3 : QLpt+1]
-//line parser.y:300
+//line parser.y:576
 		{
 			QLVAL.str = QLDollar[1].str + QLDollar[2].str + QLDollar[3].str
 		}
-	case 41:
+	case 90:
 		QLDollar = QLS[QLpt-3 : QLpt+1]
-//line parser.y:306
+//line parser.y:582
 		{
 			QLVAL.str = QLDollar[1].str + QLDollar[2].str + QLDollar[3].str
 		}
-	case 42:
+	case 91:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:312
+//line parser.y:588
 		{
 			QLVAL.str = QLDollar[1].str
 		}
-	case 43:
+	case 92:
 		QLDollar = QLS[QLpt-1 : QLpt+1]
-//line parser.y:318
+//line parser.y:594
 		{
 			QLVAL.str = strconv.FormatInt(QLDollar[1].integer, 10)
 		}
@@ -43,15 +43,16 @@ func updateStmt(QLlex interface{}, stmt Statement) {
 // any non-terminal which returns a value needs a type, which is
 // really a field name in the above union struct
 %type <stmts> STATEMENTS
-%type <stmt> INSERT_STATEMENT USE_STATEMENT SET_STATEMENT CHUNKED_STATEMENT CHUNK_SIZE_STATEMENT AUTH_STATEMENT HELP_STATEMENT PRECISION_STATEMENT TIMER_STATEMENT DEBUG_STATEMENT PROMPT_STATEMENT VERTICAL_STATEMENT
-%type <str> LINE_PROTOCOL TIME_SERIE MEASUREMENT KV_RAW KV_RAWS TIME
+%type <stmt> INSERT_STATEMENT USE_STATEMENT SET_STATEMENT CHUNKED_STATEMENT CHUNK_SIZE_STATEMENT AUTH_STATEMENT HELP_STATEMENT PRECISION_STATEMENT TIMER_STATEMENT DEBUG_STATEMENT PROMPT_STATEMENT VERTICAL_STATEMENT FORMAT_STATEMENT SHOW_SETTINGS_STATEMENT HISTORY_STATEMENT PAGER_STATEMENT OUTPUT_STATEMENT VARIABLE_STATEMENT SAVED_QUERY_STATEMENT WATCH_STATEMENT CONNECT_STATEMENT STATUS_STATEMENT
+%type <str> LINE_PROTOCOL TIME_SERIE MEASUREMENT KV_RAW KV_RAWS TIME SETTING_KEY
 %type <integer> NUM_CHUNK_SIZE
-%type <strslice> NAMESPACE
+%type <strslice> NAMESPACE CONNECT_ARGS
 %type <pair> KEY_VALUE
 %type <pairs> KEY_VALUES
 
 // same for terminals
-%token <str> INSERT INTO USE SET CHUNKED CHUNK_SIZE AUTH HELP PRECISION TIMER DEBUG PROMPT VERTICAL
+%token <str> INSERT INTO USE SET CHUNKED CHUNK_SIZE AUTH HELP PRECISION TIMER DEBUG PROMPT VERTICAL FORMAT SHOW SETTINGS HISTORY PAGER WATCH CONNECT STATUS
+%token <str> BACKSLASH_O BACKSLASH_TEE BACKSLASH_SET BACKSLASH_UNSET BACKSLASH_SAVE BACKSLASH_RUN BACKSLASH_LIST REDIRECT_APPEND
 %token <str> DOT COMMA
 %token <str> EQ
 %token <str> IDENT
@@ -111,6 +112,46 @@ STATEMENTS:
     {
         updateStmt(QLlex, $1)
     }
+    |FORMAT_STATEMENT
+    {
+        updateStmt(QLlex, $1)
+    }
+    |SHOW_SETTINGS_STATEMENT
+    {
+        updateStmt(QLlex, $1)
+    }
+    |HISTORY_STATEMENT
+    {
+        updateStmt(QLlex, $1)
+    }
+    |PAGER_STATEMENT
+    {
+        updateStmt(QLlex, $1)
+    }
+    |OUTPUT_STATEMENT
+    {
+        updateStmt(QLlex, $1)
+    }
+    |VARIABLE_STATEMENT
+    {
+        updateStmt(QLlex, $1)
+    }
+    |SAVED_QUERY_STATEMENT
+    {
+        updateStmt(QLlex, $1)
+    }
+    |WATCH_STATEMENT
+    {
+        updateStmt(QLlex, $1)
+    }
+    |CONNECT_STATEMENT
+    {
+        updateStmt(QLlex, $1)
+    }
+    |STATUS_STATEMENT
+    {
+        updateStmt(QLlex, $1)
+    }
 
 SET_STATEMENT:
     SET KEY_VALUES
@@ -228,6 +269,199 @@ VERTICAL_STATEMENT:
         $$ = stmt
     }
 
+FORMAT_STATEMENT:
+    FORMAT
+    {
+        stmt := &FormatStatement{}
+        $$ = stmt
+    }
+    |FORMAT IDENT
+    {
+        stmt := &FormatStatement{}
+        stmt.Format = $2
+        $$ = stmt
+    }
+
+SHOW_SETTINGS_STATEMENT:
+    SHOW SETTINGS
+    {
+        stmt := &ShowSettingsStatement{}
+        $$ = stmt
+    }
+
+HISTORY_STATEMENT:
+    HISTORY
+    {
+        stmt := &HistoryStatement{}
+        $$ = stmt
+    }
+    |HISTORY INTEGER
+    {
+        stmt := &HistoryStatement{}
+        stmt.Limit = $2
+        $$ = stmt
+    }
+
+PAGER_STATEMENT:
+    PAGER
+    {
+        stmt := &PagerStatement{}
+        $$ = stmt
+    }
+    |PAGER IDENT
+    {
+        stmt := &PagerStatement{}
+        stmt.Pager = $2
+        $$ = stmt
+    }
+    |PAGER STRING
+    {
+        stmt := &PagerStatement{}
+        stmt.Pager = $2
+        $$ = stmt
+    }
+
+OUTPUT_STATEMENT:
+    BACKSLASH_O
+    {
+        stmt := &OutputStatement{}
+        $$ = stmt
+    }
+    |BACKSLASH_O STRING
+    {
+        stmt := &OutputStatement{}
+        stmt.File = $2
+        $$ = stmt
+    }
+    |BACKSLASH_O REDIRECT_APPEND STRING
+    {
+        stmt := &OutputStatement{}
+        stmt.File = $3
+        stmt.Append = true
+        $$ = stmt
+    }
+    |BACKSLASH_TEE
+    {
+        stmt := &OutputStatement{}
+        stmt.Tee = true
+        $$ = stmt
+    }
+    |BACKSLASH_TEE STRING
+    {
+        stmt := &OutputStatement{}
+        stmt.File = $2
+        stmt.Tee = true
+        $$ = stmt
+    }
+    |BACKSLASH_TEE REDIRECT_APPEND STRING
+    {
+        stmt := &OutputStatement{}
+        stmt.File = $3
+        stmt.Append = true
+        stmt.Tee = true
+        $$ = stmt
+    }
+
+VARIABLE_STATEMENT:
+    BACKSLASH_SET
+    {
+        stmt := &SetVariableStatement{}
+        $$ = stmt
+    }
+    |BACKSLASH_SET IDENT
+    {
+        stmt := &SetVariableStatement{}
+        stmt.Name = $2
+        $$ = stmt
+    }
+    |BACKSLASH_SET IDENT STRING
+    {
+        stmt := &SetVariableStatement{}
+        stmt.Name = $2
+        stmt.Value = $3
+        $$ = stmt
+    }
+    |BACKSLASH_UNSET IDENT
+    {
+        stmt := &UnsetVariableStatement{}
+        stmt.Name = $2
+        $$ = stmt
+    }
+
+SAVED_QUERY_STATEMENT:
+    BACKSLASH_SAVE IDENT
+    {
+        stmt := &SaveQueryStatement{}
+        stmt.Name = $2
+        $$ = stmt
+    }
+    |BACKSLASH_SAVE IDENT STRING
+    {
+        stmt := &SaveQueryStatement{}
+        stmt.Name = $2
+        stmt.Statement = $3
+        $$ = stmt
+    }
+    |BACKSLASH_RUN IDENT
+    {
+        stmt := &RunQueryStatement{}
+        stmt.Name = $2
+        $$ = stmt
+    }
+    |BACKSLASH_RUN IDENT KEY_VALUES
+    {
+        stmt := &RunQueryStatement{}
+        stmt.Name = $2
+        stmt.Variables = $3
+        $$ = stmt
+    }
+    |BACKSLASH_LIST
+    {
+        stmt := &ListQueriesStatement{}
+        $$ = stmt
+    }
+
+WATCH_STATEMENT:
+    WATCH INTEGER STRING
+    {
+        stmt := &WatchStatement{}
+        stmt.Seconds = float64($2)
+        stmt.Statement = $3
+        $$ = stmt
+    }
+    |WATCH DECIMAL STRING
+    {
+        stmt := &WatchStatement{}
+        stmt.Seconds = $2
+        stmt.Statement = $3
+        $$ = stmt
+    }
+
+CONNECT_STATEMENT:
+    CONNECT CONNECT_ARGS
+    {
+        stmt := &ConnectStatement{}
+        stmt.Args = $2
+        $$ = stmt
+    }
+
+CONNECT_ARGS:
+    STRING
+    {
+        $$ = []string{$1}
+    }
+    |STRING CONNECT_ARGS
+    {
+        $$ = append([]string{$1}, $2...)
+    }
+
+STATUS_STATEMENT:
+    STATUS
+    {
+        stmt := &StatusStatement{}
+        $$ = stmt
+    }
+
 NAMESPACE:
     IDENT
     {
@@ -260,22 +494,22 @@ TIME_SERIE:
     }
 
 KEY_VALUE:
-    IDENT EQ IDENT
+    SETTING_KEY EQ IDENT
     {
         p := NewPair($1, $3)
         $$ = *p
     }
-    |IDENT EQ STRING
+    |SETTING_KEY EQ STRING
     {
         p := NewPair($1, $3)
         $$ = *p
     }
-    |IDENT EQ INTEGER
+    |SETTING_KEY EQ INTEGER
     {
         p := NewPair($1, $3)
         $$ = *p
     }
-    |IDENT EQ DECIMAL
+    |SETTING_KEY EQ DECIMAL
     {
         p := NewPair($1, $3)
         $$ = *p
@@ -288,7 +522,49 @@ KEY_VALUES:
     }
     |KEY_VALUE COMMA KEY_VALUES
     {
-        $$ = append($3, $1)
+        $$ = append(Pairs{$1}, $3...)
+    }
+
+SETTING_KEY:
+    IDENT
+    {
+        $$ = $1
+    }
+    |PRECISION
+    {
+        $$ = $1
+    }
+    |TIMER
+    {
+        $$ = $1
+    }
+    |DEBUG
+    {
+        $$ = $1
+    }
+    |PROMPT
+    {
+        $$ = $1
+    }
+    |VERTICAL
+    {
+        $$ = $1
+    }
+    |FORMAT
+    {
+        $$ = $1
+    }
+    |CHUNKED
+    {
+        $$ = $1
+    }
+    |CHUNK_SIZE
+    {
+        $$ = $1
+    }
+    |PAGER
+    {
+        $$ = $1
     }
 
 KV_RAWS:
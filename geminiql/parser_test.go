@@ -276,6 +276,209 @@ func TestParser(t *testing.T) {
 				Precision: "ns",
 			},
 		},
+		{
+			name: "set float precision",
+			cmd:  "set float_precision=4",
+			expect: &SetStatement{
+				KVS: []Pair{*NewPair("float_precision", int64(4))},
+			},
+		},
+		{
+			name: "set keyword settings",
+			cmd:  "set timer=on, format=csv, null_display='(null)'",
+			expect: &SetStatement{
+				KVS: []Pair{*NewPair("timer", "on"), *NewPair("format", "csv"), *NewPair("null_display", "(null)")},
+			},
+		},
+		{
+			name:   "show settings",
+			cmd:    "show settings",
+			expect: &ShowSettingsStatement{},
+		},
+		{
+			name:   "show history",
+			cmd:    "history",
+			expect: &HistoryStatement{},
+		},
+		{
+			name: "show last history entries",
+			cmd:  "history 10",
+			expect: &HistoryStatement{
+				Limit: 10,
+			},
+		},
+		{
+			name:   "toggle pager",
+			cmd:    "pager",
+			expect: &PagerStatement{},
+		},
+		{
+			name: "turn off pager",
+			cmd:  "pager off",
+			expect: &PagerStatement{
+				Pager: "off",
+			},
+		},
+		{
+			name: "set pager command",
+			cmd:  "pager 'less -RS'",
+			expect: &PagerStatement{
+				Pager: "less -RS",
+			},
+		},
+		{
+			name: "set pager",
+			cmd:  "set pager=more",
+			expect: &SetStatement{
+				KVS: []Pair{*NewPair("pager", "more")},
+			},
+		},
+		{
+			name: "redirect output",
+			cmd:  `\o /tmp/out.txt`,
+			expect: &OutputStatement{
+				File: "/tmp/out.txt",
+			},
+		},
+		{
+			name: "redirect output appending",
+			cmd:  `\o >> 'my results.csv'`,
+			expect: &OutputStatement{
+				File:   "my results.csv",
+				Append: true,
+			},
+		},
+		{
+			name: "tee output",
+			cmd:  `\tee out.txt `,
+			expect: &OutputStatement{
+				File: "out.txt",
+				Tee:  true,
+			},
+		},
+		{
+			name:   "reset output",
+			cmd:    `\o`,
+			expect: &OutputStatement{},
+		},
+		{
+			name:   "list variables",
+			cmd:    `\set`,
+			expect: &SetVariableStatement{},
+		},
+		{
+			name: "set variable",
+			cmd:  `\set host server01`,
+			expect: &SetVariableStatement{
+				Name:  "host",
+				Value: "server01",
+			},
+		},
+		{
+			name: "set variable to the rest of the input",
+			cmd:  `\set timer now() - 1h `,
+			expect: &SetVariableStatement{
+				Name:  "timer",
+				Value: "now() - 1h",
+			},
+		},
+		{
+			name: "set quoted variable",
+			cmd:  `\set host 'server 01'`,
+			expect: &SetVariableStatement{
+				Name:  "host",
+				Value: "server 01",
+			},
+		},
+		{
+			name: "unset variable",
+			cmd:  `\unset host`,
+			expect: &UnsetVariableStatement{
+				Name: "host",
+			},
+		},
+		{
+			name: "save last statement",
+			cmd:  `\save slow`,
+			expect: &SaveQueryStatement{
+				Name: "slow",
+			},
+		},
+		{
+			name: "save statement",
+			cmd:  `\save load SELECT mean(load) FROM cpu WHERE host = :'host'`,
+			expect: &SaveQueryStatement{
+				Name:      "load",
+				Statement: `SELECT mean(load) FROM cpu WHERE host = :'host'`,
+			},
+		},
+		{
+			name: "run saved query",
+			cmd:  `\run slow`,
+			expect: &RunQueryStatement{
+				Name: "slow",
+			},
+		},
+		{
+			name: "run saved query with variables",
+			cmd:  `\run load host=server01, since='now() - 1h'`,
+			expect: &RunQueryStatement{
+				Name:      "load",
+				Variables: Pairs{*NewPair("host", "server01"), *NewPair("since", "now() - 1h")},
+			},
+		},
+		{
+			name:   "list saved queries",
+			cmd:    `\list`,
+			expect: &ListQueriesStatement{},
+		},
+		{
+			name: "watch statement",
+			cmd:  "watch 5 SELECT count(*) FROM cpu WHERE time > now()-1m",
+			expect: &WatchStatement{
+				Seconds:   5,
+				Statement: "SELECT count(*) FROM cpu WHERE time > now()-1m",
+			},
+		},
+		{
+			name: "watch statement every half second",
+			cmd:  "watch 0.5 SHOW QUERIES",
+			expect: &WatchStatement{
+				Seconds:   0.5,
+				Statement: "SHOW QUERIES",
+			},
+		},
+		{
+			name: "connect statement",
+			cmd:  "connect db.example.com:8443 --ssl --user 'the admin'",
+			expect: &ConnectStatement{
+				Args: []string{"db.example.com:8443", "--ssl", "--user", "the admin"},
+			},
+		},
+		{
+			name: "connect meta command",
+			cmd:  `\connect localhost`,
+			expect: &ConnectStatement{
+				Args: []string{"localhost"},
+			},
+		},
+		{
+			name:   "status statement",
+			cmd:    "status",
+			expect: &StatusStatement{},
+		},
+		{
+			name: "set output format",
+			cmd:  "format csv",
+			expect: &FormatStatement{
+				Format: "csv",
+			},
+		},
+		{
+			name:   "show output format",
+			cmd:    "format",
+			expect: &FormatStatement{},
+		},
 		{
 			name: "tag array write with multi values",
 			cmd:  "insert cpu,t1=1,t2=[a,b] value=3",
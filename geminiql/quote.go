@@ -0,0 +1,43 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geminiql
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/openGemini/openGemini/lib/util/lifted/influx/influxql"
+)
+
+var plainIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// QuoteIdent double quotes the identifier unless it only contains letters, digits and underscores
+// and is not an InfluxQL keyword, which the parser refuses as a bare identifier
+func QuoteIdent(name string) string {
+	if plainIdent.MatchString(name) && influxql.Lookup(name) == influxql.IDENT {
+		return name
+	}
+	return DoubleQuote(name)
+}
+
+// DoubleQuote double quotes the identifier whatever it contains
+func DoubleQuote(name string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(name) + `"`
+}
+
+// QuoteString single quotes the string literal
+func QuoteString(s string) string {
+	return `'` + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + `'`
+}
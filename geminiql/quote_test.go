@@ -0,0 +1,31 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geminiql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteIdent(t *testing.T) {
+	require.Equal(t, "cpu_load", QuoteIdent("cpu_load"))
+	require.Equal(t, `"cpu load"`, QuoteIdent("cpu load"))
+	require.Equal(t, `"1cpu"`, QuoteIdent("1cpu"))
+	require.Equal(t, `"from"`, QuoteIdent("from"))
+	require.Equal(t, `"Select"`, QuoteIdent("Select"))
+	require.Equal(t, `"a\"b\\c"`, QuoteIdent(`a"b\c`))
+	require.Equal(t, `"cpu"`, DoubleQuote("cpu"))
+}
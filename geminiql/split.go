@@ -0,0 +1,121 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geminiql
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SplitStatements splits the input into the statements terminated by an unquoted `;`, the
+// statements are trimmed and empty ones are dropped. The text after the last terminator is
+// returned as rest, it is an incomplete statement unless it is blank.
+//
+// Quoted strings and backslash escapes never terminate a statement. INSERT statements follow
+// the raw line protocol instead: the measurement and the tags are taken as they are, quotes and
+// `;` included, only the double quoted field values are strings, and the statement ends at a `;`
+// in the fields followed by a blank, e.g. `INSERT cpu,owner=it's,path=a;b value="a;b";`.
+func SplitStatements(input string) (statements []string, rest string) {
+	runes := []rune(input)
+	var start int
+	for {
+		end := statementEnd(runes[start:])
+		if end < 0 {
+			return statements, string(runes[start:])
+		}
+		if statement := strings.TrimSpace(string(runes[start : start+end])); statement != "" {
+			statements = append(statements, statement)
+		}
+		start += end + 1
+	}
+}
+
+// statementEnd is the index of the `;` terminating the first statement, -1 if it is incomplete
+func statementEnd(runes []rune) int {
+	if from := lineProtocolStart(runes); from >= 0 {
+		return lineProtocolEnd(runes, from)
+	}
+	var quote rune
+	for i := 0; i < len(runes); i++ {
+		switch ch := runes[i]; {
+		case ch == '\\':
+			i++
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == ';':
+			return i
+		}
+	}
+	return -1
+}
+
+// lineProtocolStart is the index the line protocol of an INSERT statement starts at, after
+// `INSERT` or `INSERT INTO <rp>`, -1 if the statement is not an INSERT
+func lineProtocolStart(runes []rune) int {
+	i := skipSpaces(runes, 0)
+	word, next := wordAt(runes, i)
+	if !strings.EqualFold(word, "INSERT") || next == len(runes) || !unicode.IsSpace(runes[next]) {
+		return -1
+	}
+	i = skipSpaces(runes, next)
+	if word, next = wordAt(runes, i); strings.EqualFold(word, "INTO") && next < len(runes) {
+		// the retention policy follows INTO
+		_, next = wordAt(runes, skipSpaces(runes, next))
+		i = skipSpaces(runes, next)
+	}
+	return i
+}
+
+// lineProtocolEnd is the index of the `;` terminating the line protocol starting at from, -1
+// if it is incomplete. A `;` in the measurement, the tags or a string field value is data.
+func lineProtocolEnd(runes []rune, from int) int {
+	var fields, quoted bool
+	for i := from; i < len(runes); i++ {
+		switch ch := runes[i]; {
+		case ch == '\\':
+			i++
+		case quoted:
+			quoted = ch != '"'
+		case unicode.IsSpace(ch):
+			fields = true
+		case !fields:
+		case ch == '"':
+			quoted = true
+		case ch == ';' && (i+1 == len(runes) || unicode.IsSpace(runes[i+1])):
+			return i
+		}
+	}
+	return -1
+}
+
+func skipSpaces(runes []rune, i int) int {
+	for i < len(runes) && unicode.IsSpace(runes[i]) {
+		i++
+	}
+	return i
+}
+
+// wordAt returns the word at i and the index after it, the word ends at a blank or a `;`
+func wordAt(runes []rune, i int) (string, int) {
+	start := i
+	for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != ';' {
+		i++
+	}
+	return string(runes[start:i]), i
+}
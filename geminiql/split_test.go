@@ -0,0 +1,52 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geminiql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitStatements(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		input      string
+		statements []string
+		rest       string
+	}{
+		{name: "incomplete", input: "SELECT * FROM cpu", rest: "SELECT * FROM cpu"},
+		{name: "single", input: "SELECT * FROM cpu;", statements: []string{"SELECT * FROM cpu"}},
+		{name: "multi line", input: "SELECT *\nFROM cpu\nWHERE host = 'a';\n", statements: []string{"SELECT *\nFROM cpu\nWHERE host = 'a'"}, rest: "\n"},
+		{name: "several on one line", input: "use db0; SHOW MEASUREMENTS;;SHOW", statements: []string{"use db0", "SHOW MEASUREMENTS"}, rest: "SHOW"},
+		{name: "quoted terminators", input: `SELECT "a;b" FROM cpu WHERE host = 'x;y';`, statements: []string{`SELECT "a;b" FROM cpu WHERE host = 'x;y'`}},
+		{name: "escaped quote", input: `SELECT * FROM cpu WHERE host = 'it\'s;'; SHOW DATABASES;`, statements: []string{`SELECT * FROM cpu WHERE host = 'it\'s;'`, "SHOW DATABASES"}},
+		{name: "open quote", input: "SELECT * FROM cpu WHERE host = 'a;\n", rest: "SELECT * FROM cpu WHERE host = 'a;\n"},
+		{name: "insert single quote", input: `INSERT cpu,owner=it's value="a;b"; insert cpu value=1;`, statements: []string{`INSERT cpu,owner=it's value="a;b"`, "insert cpu value=1"}},
+		{name: "insert escaped", input: `insert cpu,host=a\;b value=1;`, statements: []string{`insert cpu,host=a\;b value=1`}},
+		{name: "insert quoted field", input: `insert m f="a;b",g="c; d" 1; SHOW DATABASES;`, statements: []string{`insert m f="a;b",g="c; d" 1`, "SHOW DATABASES"}},
+		{name: "insert quoted tag", input: `insert m,t='a;b',u="c;d",path=e;f g=1;`, statements: []string{`insert m,t='a;b',u="c;d",path=e;f g=1`}},
+		{name: "insert literal quote in tag", input: `insert m,t=a"b f=1; insert m f="x\";y";`, statements: []string{`insert m,t=a"b f=1`, `insert m f="x\";y"`}},
+		{name: "insert into", input: `INSERT INTO rp0 m,t=a;b f="x;y";`, statements: []string{`INSERT INTO rp0 m,t=a;b f="x;y"`}},
+		{name: "insert open string", input: `insert m f="a;`, rest: `insert m f="a;`},
+		{name: "insert keyword only", input: "insert;", statements: []string{"insert"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			statements, rest := SplitStatements(tc.input)
+			require.Equal(t, tc.statements, statements)
+			require.Equal(t, tc.rest, rest)
+		})
+	}
+}
@@ -3,6 +3,7 @@ module github.com/openGemini/openGemini-cli
 go 1.24
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/VictoriaMetrics/VictoriaMetrics v1.102.1
 	github.com/golang/snappy v1.0.0
 	github.com/mattn/go-runewidth v0.0.16
@@ -11,6 +12,7 @@ require (
 	github.com/openGemini/openGemini v1.4.3
 	github.com/openGemini/opengemini-client-go v0.9.1
 	github.com/spf13/cobra v1.9.1
+	github.com/spf13/pflag v1.0.6
 	github.com/stretchr/testify v1.11.1
 	github.com/valyala/fastjson v1.6.4
 	github.com/vbauerster/mpb/v7 v7.3.2
@@ -19,7 +21,6 @@ require (
 )
 
 require (
-	github.com/BurntSushi/toml v1.3.2 // indirect
 	github.com/JohnCGriffin/overflow v0.0.0-20211019200055-46fa312c352c // indirect
 	github.com/RoaringBitmap/roaring v1.9.4 // indirect
 	github.com/VictoriaMetrics/fastcache v1.12.2 // indirect
@@ -120,7 +121,6 @@ require (
 	github.com/savsgio/gotils v0.0.0-20220530130905-52f3993e8d6d // indirect
 	github.com/sean-/seed v0.0.0-20170313163322-e2103e2c3529 // indirect
 	github.com/shirou/gopsutil/v3 v3.24.5 // indirect
-	github.com/spf13/pflag v1.0.6 // indirect
 	github.com/tinylib/msgp v1.1.8 // indirect
 	github.com/tklauser/go-sysconf v0.3.12 // indirect
 	github.com/tklauser/numcpus v0.6.1 // indirect
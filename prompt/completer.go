@@ -15,11 +15,29 @@
 package prompt
 
 import (
+	"os"
+	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/openGemini/go-prompt"
+
+	"github.com/openGemini/openGemini-cli/geminiql"
 )
 
+// Metadata provides the identifiers of the server which are suggested by the completer,
+// the measurements and keys belong to the current database
+type Metadata interface {
+	Databases() []string
+	Measurements() []string
+	TagKeys(measurement string) []string
+	FieldKeys(measurement string) []string
+	TagValues(measurement, key string) []string
+}
+
+// wordSeparator splits the word being completed, "=" is included to complete tag values like host='server01'
+var wordSeparator = string([]byte{' ', '=', os.PathSeparator})
+
 type Completer struct {
 	suggestions    []prompt.Suggest
 	aggregateFuncs []prompt.Suggest
@@ -27,11 +45,13 @@ type Completer struct {
 	operators      []prompt.Suggest
 	filters        []prompt.Suggest
 
-	suggest bool
+	metadata Metadata
+	queries  *SavedQueries
+	suggest  bool
 }
 
-func NewCompleter() *Completer {
-	c := &Completer{}
+func NewCompleter(metadata Metadata, queries *SavedQueries) *Completer {
+	c := &Completer{metadata: metadata, queries: queries}
 
 	// Initialize aggregate functions
 	c.aggregateFuncs = []prompt.Suggest{
@@ -154,13 +174,34 @@ func (c *Completer) completer(d prompt.Document) []prompt.Suggest {
 
 	line := d.TextBeforeCursor()
 	words := strings.Fields(line)
-	word := d.GetWordBeforeCursor()
+	word := d.GetWordBeforeCursorUntilSeparator(wordSeparator)
 
 	// 如果是空行，返回所有一级命令
 	if len(words) == 0 {
 		return c.suggestions
 	}
 
+	// 光标所在词之前的内容，用于判断需要补全的标识符类型
+	before := strings.TrimRightFunc(strings.TrimSuffix(line, word), unicode.IsSpace)
+	previous := strings.Fields(before)
+	if len(previous) > 0 {
+		switch strings.ToUpper(previous[len(previous)-1]) {
+		case "USE", "ON":
+			return filterSuggestions(c.identifiers(c.databases(), "Database"), word)
+		case "FROM", "MEASUREMENT":
+			suggestions = c.identifiers(c.measurements(), "Measurement")
+			if len(suggestions) == 0 {
+				suggestions = []prompt.Suggest{{Text: "<measurement_name>", Description: "Enter measurement name"}}
+			}
+			return filterSuggestions(suggestions, word)
+		case `\RUN`, `\SAVE`:
+			// 已保存查询的名称
+			if len(previous) == 1 {
+				return filterSuggestions(c.savedQueries(), word)
+			}
+		}
+	}
+
 	// 根据第一个命令词来判断
 	switch strings.ToUpper(words[0]) {
 	case "SHOW":
@@ -181,11 +222,11 @@ func (c *Completer) completer(d prompt.Document) []prompt.Suggest {
 		}
 		// 如果当前输入只有 SHOW，则返回所有二级命令
 		if len(words) == 1 && !strings.HasSuffix(line, " ") {
-			return prompt.FilterHasPrefix(suggestions, word, true)
+			return filterSuggestions(suggestions, word)
 		}
 		// 如果正在输入第二个词，则根据前缀过滤二级命令
 		if len(words) <= 2 {
-			return prompt.FilterHasPrefix(suggestions, word, true)
+			return filterSuggestions(suggestions, word)
 		}
 	case "CREATE":
 		suggestions = []prompt.Suggest{
@@ -201,27 +242,37 @@ func (c *Completer) completer(d prompt.Document) []prompt.Suggest {
 			{Text: "RETENTION POLICY", Description: "Drop retention policy"},
 		}
 	case "SELECT":
-		if len(words) <= 2 {
+		measurement := measurementOf(d.Text)
+		switch lastClause(previous) {
+		case "SELECT":
+			suggestions = append(suggestions, c.keys(measurement)...)
 			suggestions = append(suggestions, c.aggregateFuncs...)
 			suggestions = append(suggestions, []prompt.Suggest{
 				{Text: "*", Description: "Select all fields"},
 				{Text: "FROM", Description: "Specify data source"},
 			}...)
-		} else if strings.HasSuffix(line, "FROM ") {
-			suggestions = []prompt.Suggest{
-				{Text: "<measurement_name>", Description: "Enter measurement name"},
+		case "WHERE":
+			if key, ok := tagValueKey(before); ok {
+				return filterSuggestions(c.tagValues(measurement, key), word)
+			}
+			suggestions = append(suggestions, c.keys(measurement)...)
+			if len(suggestions) == 0 {
+				suggestions = []prompt.Suggest{
+					{Text: "<tag_name>", Description: "Enter tag name"},
+					{Text: "<tag_value>", Description: "Enter tag value"},
+				}
 			}
-		} else if strings.Contains(line, "WHERE") {
-			suggestions = append(suggestions, []prompt.Suggest{
-				{Text: "<tag_name>", Description: "Enter tag name"},
-				{Text: "<tag_value>", Description: "Enter tag value"},
-			}...)
 			suggestions = append(suggestions, c.operators...)
 			suggestions = append(suggestions, c.timeFuncs...)
-		} else if len(words) >= 4 {
-			suggestions = append(suggestions, c.filters...)
-			suggestions = append(suggestions, c.operators...)
+		case "GROUP BY":
+			suggestions = append(suggestions, c.identifiers(c.tagKeys(measurement), "Tag key")...)
 			suggestions = append(suggestions, c.timeFuncs...)
+		default:
+			if len(words) >= 4 {
+				suggestions = append(suggestions, c.filters...)
+				suggestions = append(suggestions, c.operators...)
+				suggestions = append(suggestions, c.timeFuncs...)
+			}
 		}
 	}
 
@@ -230,7 +281,131 @@ func (c *Completer) completer(d prompt.Document) []prompt.Suggest {
 		suggestions = c.suggestions
 	}
 
-	return prompt.FilterHasPrefix(suggestions, word, true)
+	return filterSuggestions(suggestions, word)
+}
+
+func (c *Completer) databases() []string {
+	if c.metadata == nil {
+		return nil
+	}
+	return c.metadata.Databases()
+}
+
+func (c *Completer) measurements() []string {
+	if c.metadata == nil {
+		return nil
+	}
+	return c.metadata.Measurements()
+}
+
+func (c *Completer) tagKeys(measurement string) []string {
+	if c.metadata == nil || measurement == "" {
+		return nil
+	}
+	return c.metadata.TagKeys(measurement)
+}
+
+// keys returns the field keys and tag keys of the measurement
+func (c *Completer) keys(measurement string) []prompt.Suggest {
+	if c.metadata == nil || measurement == "" {
+		return nil
+	}
+	suggestions := c.identifiers(c.metadata.FieldKeys(measurement), "Field key")
+	return append(suggestions, c.identifiers(c.metadata.TagKeys(measurement), "Tag key")...)
+}
+
+func (c *Completer) tagValues(measurement, key string) []prompt.Suggest {
+	if c.metadata == nil || measurement == "" {
+		return nil
+	}
+	var suggestions []prompt.Suggest
+	for _, value := range c.metadata.TagValues(measurement, key) {
+		suggestions = append(suggestions, prompt.Suggest{Text: geminiql.QuoteString(value), Description: "Tag value"})
+	}
+	return suggestions
+}
+
+func (c *Completer) savedQueries() []prompt.Suggest {
+	var suggestions []prompt.Suggest
+	for _, name := range c.queries.Names() {
+		statement, _ := c.queries.Get(name)
+		suggestions = append(suggestions, prompt.Suggest{Text: name, Description: statement})
+	}
+	return suggestions
+}
+
+func (c *Completer) identifiers(names []string, description string) []prompt.Suggest {
+	var suggestions = make([]prompt.Suggest, 0, len(names))
+	for _, name := range names {
+		suggestions = append(suggestions, prompt.Suggest{Text: geminiql.QuoteIdent(name), Description: description})
+	}
+	return suggestions
+}
+
+// filterSuggestions keeps the suggestions starting with the word ignoring case, a quoted identifier
+// or tag value also matches the word typed without its opening quote
+func filterSuggestions(suggestions []prompt.Suggest, word string) []prompt.Suggest {
+	if word == "" {
+		return suggestions
+	}
+	word = strings.ToUpper(word)
+	var filtered []prompt.Suggest
+	for _, s := range suggestions {
+		text := strings.ToUpper(s.Text)
+		if strings.HasPrefix(text, word) || strings.HasPrefix(strings.TrimLeft(text, `"'`), word) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+var fromMeasurement = regexp.MustCompile(`(?i)\bFROM\s+([^\s,;()]+)`)
+
+// measurementOf finds the measurement of the query, the database and retention policy of a
+// fully qualified measurement like db0.autogen.cpu are ignored
+func measurementOf(text string) string {
+	match := fromMeasurement.FindStringSubmatch(text)
+	if match == nil {
+		return ""
+	}
+	parts := strings.Split(match[1], ".")
+	return unquoteIdent(parts[len(parts)-1])
+}
+
+// lastClause returns the clause of the query the cursor is in
+func lastClause(words []string) string {
+	for i := len(words) - 1; i >= 0; i-- {
+		switch word := strings.ToUpper(words[i]); word {
+		case "SELECT", "FROM", "WHERE":
+			return word
+		case "BY":
+			if i > 0 && strings.EqualFold(words[i-1], "GROUP") {
+				return "GROUP BY"
+			}
+			return word
+		}
+	}
+	return ""
+}
+
+// tagValueKey returns the tag key when the input before the cursor ends with `key =` or `key !=`
+func tagValueKey(before string) (string, bool) {
+	if !strings.HasSuffix(before, "=") || strings.HasSuffix(before, ">=") || strings.HasSuffix(before, "<=") {
+		return "", false
+	}
+	before = strings.TrimSuffix(strings.TrimSuffix(before, "="), "!")
+	fields := strings.FieldsFunc(before, func(r rune) bool { return unicode.IsSpace(r) || r == '(' || r == ',' })
+	if len(fields) == 0 {
+		return "", false
+	}
+	return unquoteIdent(fields[len(fields)-1]), true
+}
+
+func unquoteIdent(name string) string {
+	if len(name) >= 2 && name[0] == '"' && name[len(name)-1] == '"' {
+		return strings.ReplaceAll(name[1:len(name)-1], `\"`, `"`)
+	}
+	return name
 }
 
 func (c *Completer) switchCompleter(s bool) {
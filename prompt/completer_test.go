@@ -0,0 +1,113 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openGemini/go-prompt"
+	"github.com/stretchr/testify/require"
+)
+
+type testMetadata struct{}
+
+func (testMetadata) Databases() []string    { return []string{"db0", "my-db"} }
+func (testMetadata) Measurements() []string { return []string{"cpu", "mem"} }
+func (testMetadata) TagKeys(measurement string) []string {
+	if measurement == "cpu" {
+		return []string{"host", "key", "region"}
+	}
+	return nil
+}
+func (testMetadata) FieldKeys(measurement string) []string {
+	if measurement == "cpu" {
+		return []string{"usage"}
+	}
+	return nil
+}
+func (testMetadata) TagValues(measurement, key string) []string {
+	if measurement == "cpu" && key == "host" {
+		return []string{"server01", "server02"}
+	}
+	return nil
+}
+
+// suggestionTexts completes the text with the cursor at the end or at the "|" in the text
+func suggestionTexts(c *Completer, text string) []string {
+	before, after, _ := strings.Cut(text, "|")
+	buf := prompt.NewBuffer()
+	buf.InsertText(before+after, false, true)
+	buf.CursorLeft(len([]rune(after)))
+	var texts []string
+	for _, s := range c.completer(*buf.Document()) {
+		texts = append(texts, s.Text)
+	}
+	return texts
+}
+
+func TestCompleter_Identifiers(t *testing.T) {
+	c := NewCompleter(testMetadata{}, nil)
+	c.switchCompleter(true)
+
+	require.Equal(t, []string{"db0", `"my-db"`}, suggestionTexts(c, "use "))
+	require.Equal(t, []string{`"my-db"`}, suggestionTexts(c, `SHOW MEASUREMENTS ON "m`))
+	require.Equal(t, []string{`"my-db"`}, suggestionTexts(c, "use my"))
+	require.Equal(t, []string{"cpu", "mem"}, suggestionTexts(c, "SELECT * FROM "))
+	require.Equal(t, []string{"cpu"}, suggestionTexts(c, "show tag keys from c"))
+	require.Equal(t, []string{"usage"}, suggestionTexts(c, "SELECT us| FROM cpu"))
+	require.Equal(t, []string{"host"}, suggestionTexts(c, "SELECT usage FROM db0.autogen.cpu WHERE hos"))
+	require.Equal(t, []string{"region"}, suggestionTexts(c, "SELECT usage FROM cpu GROUP BY r"))
+	// the keywords are quoted to be accepted as identifiers
+	require.Equal(t, []string{`"key"`}, suggestionTexts(c, "SELECT usage FROM cpu GROUP BY k"))
+	require.Equal(t, []string{"'server01'", "'server02'"}, suggestionTexts(c, "SELECT usage FROM cpu WHERE host = "))
+	require.Equal(t, []string{"'server02'"}, suggestionTexts(c, `SELECT usage FROM cpu WHERE "host"!='server02`))
+	require.Contains(t, suggestionTexts(c, "SELECT usage FROM cpu WHERE time >"), ">=")
+}
+
+func TestCompleter_WithoutMetadata(t *testing.T) {
+	c := NewCompleter(nil, nil)
+	c.switchCompleter(true)
+	require.Equal(t, []string{"<measurement_name>"}, suggestionTexts(c, "SELECT * FROM "))
+	require.Contains(t, suggestionTexts(c, "SELECT "), "*")
+	require.Contains(t, suggestionTexts(c, "SELECT * FROM cpu WHERE "), "<tag_name>")
+}
+
+func TestCompleter_Keywords(t *testing.T) {
+	c := NewCompleter(testMetadata{}, nil)
+	c.switchCompleter(true)
+
+	show := suggestionTexts(c, "SHOW ")
+	for _, keyword := range []string{"DATABASES", "MEASUREMENTS", "SERIES", "SHARDS", "CLUSTER", "USERS", "TAG KEYS"} {
+		require.Contains(t, show, keyword)
+	}
+	require.Equal(t, []string{"DATABASE"}, suggestionTexts(c, "CREATE DATA"))
+	require.Equal(t, []string{"SUBSCRIPTION"}, suggestionTexts(c, "CREATE SUB"))
+	require.Equal(t, []string{"MEASUREMENT"}, suggestionTexts(c, "DROP MEAS"))
+	require.Equal(t, []string{"SERIES"}, suggestionTexts(c, "DROP SER"))
+	require.Equal(t, []string{"FROM"}, suggestionTexts(c, "SELECT usage FR"))
+}
+
+func TestCompleter_SavedQueries(t *testing.T) {
+	queries := NewSavedQueries(filepath.Join(t.TempDir(), "queries.toml"))
+	require.NoError(t, queries.Save("slow", "SHOW QUERIES"))
+	require.NoError(t, queries.Save("load", "SELECT load FROM cpu"))
+	c := NewCompleter(nil, queries)
+	c.switchCompleter(true)
+
+	require.Equal(t, []string{"load", "slow"}, suggestionTexts(c, `\run `))
+	require.Equal(t, []string{"slow"}, suggestionTexts(c, `\save s`))
+}
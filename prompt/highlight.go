@@ -0,0 +1,152 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/openGemini/go-prompt"
+
+	"github.com/openGemini/openGemini-cli/geminiql"
+)
+
+// ThemeOff disables the syntax highlighting
+const ThemeOff = "off"
+
+// Theme is the colors of the highlighted tokens of the input
+type Theme map[geminiql.TokenKind]prompt.Color
+
+var themes = map[string]Theme{
+	"dark": {
+		geminiql.TokenKeyword:  prompt.Blue,
+		geminiql.TokenFunction: prompt.Fuchsia,
+		geminiql.TokenString:   prompt.Green,
+		geminiql.TokenNumber:   prompt.Turquoise,
+		geminiql.TokenDuration: prompt.Yellow,
+		geminiql.TokenRegex:    prompt.Red,
+	},
+	"light": {
+		geminiql.TokenKeyword:  prompt.DarkBlue,
+		geminiql.TokenFunction: prompt.Purple,
+		geminiql.TokenString:   prompt.DarkGreen,
+		geminiql.TokenNumber:   prompt.Cyan,
+		geminiql.TokenDuration: prompt.Brown,
+		geminiql.TokenRegex:    prompt.DarkRed,
+	},
+	ThemeOff: {},
+}
+
+// ThemeNames lists the themes selectable by `set theme=<name>`
+var ThemeNames = []string{"dark", "light", ThemeOff}
+
+// DefaultTheme is the theme of the shell, it is off if the NO_COLOR environment variable is set
+func DefaultTheme() string {
+	if os.Getenv("NO_COLOR") != "" {
+		return ThemeOff
+	}
+	return "dark"
+}
+
+// ValidateTheme checks the name of the theme
+func ValidateTheme(name string) error {
+	if _, ok := themes[strings.ToLower(name)]; !ok {
+		return fmt.Errorf("unknown theme %q, it must be %s", name, strings.Join(ThemeNames, ", "))
+	}
+	return nil
+}
+
+// inputMarker and prefixMarker are the text colors go-prompt is told to write the input and the
+// prefix with, the highlighter recognizes them by the colors and never writes the colors themselves
+const (
+	inputMarker  = prompt.LightGray
+	prefixMarker = prompt.Black
+)
+
+// PrefixStyle tells the kind of the connection by the color of the prompt prefix
+type PrefixStyle int
+
+const (
+	PrefixPlain PrefixStyle = iota
+	PrefixTLS
+	PrefixProduction
+)
+
+var prefixColors = map[PrefixStyle]prompt.Color{
+	PrefixPlain:      prompt.DefaultColor,
+	PrefixTLS:        prompt.Green,
+	PrefixProduction: prompt.Red,
+}
+
+// highlighter writes the input of the prompt with the colors of the theme and the prefix with
+// the color of its style, the rest of the prompt like the suggestions is written as it is
+type highlighter struct {
+	prompt.ConsoleWriter
+	theme       Theme
+	functions   map[string]bool
+	prefixStyle PrefixStyle
+	// input is set while go-prompt writes the input
+	input bool
+}
+
+func newHighlighter(out prompt.ConsoleWriter, functions []prompt.Suggest) *highlighter {
+	h := &highlighter{ConsoleWriter: out, functions: make(map[string]bool)}
+	for _, function := range functions {
+		name, _, ok := strings.Cut(function.Text, "(")
+		if ok {
+			h.functions[strings.ToUpper(name)] = true
+		}
+	}
+	return h
+}
+
+func (h *highlighter) SetColor(fg, bg prompt.Color, bold bool) {
+	h.input = false
+	switch {
+	case bg != prompt.DefaultColor:
+	case fg == inputMarker:
+		h.input = true
+		fg = prompt.DefaultColor
+	case fg == prefixMarker:
+		// the prefix is colored unless the colors are turned off
+		fg = prompt.DefaultColor
+		if len(h.theme) != 0 {
+			fg = prefixColors[h.prefixStyle]
+		}
+	}
+	h.ConsoleWriter.SetColor(fg, bg, bold)
+}
+
+func (h *highlighter) WriteStr(data string) {
+	if !h.input || len(h.theme) == 0 {
+		h.ConsoleWriter.WriteStr(data)
+		return
+	}
+	for _, token := range geminiql.Tokenize(data, h.functions) {
+		h.ConsoleWriter.SetColor(h.theme[token.Kind], prompt.DefaultColor, false)
+		h.ConsoleWriter.WriteStr(token.Text)
+	}
+	h.ConsoleWriter.SetColor(prompt.DefaultColor, prompt.DefaultColor, false)
+}
+
+// SetTheme changes the colors of the input, ThemeOff writes it without colors
+func (p *Prompt) SetTheme(name string) error {
+	if err := ValidateTheme(name); err != nil {
+		return err
+	}
+	p.highlighter.theme = themes[strings.ToLower(name)]
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/openGemini/go-prompt"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedWriter records the colors and the text written by the highlighter
+type recordedWriter struct {
+	prompt.ConsoleWriter
+	buf strings.Builder
+}
+
+func (w *recordedWriter) SetColor(fg, bg prompt.Color, bold bool) {
+	if fg != prompt.DefaultColor {
+		_, _ = fmt.Fprintf(&w.buf, "<%d>", fg)
+	}
+}
+
+func (w *recordedWriter) WriteStr(data string) {
+	w.buf.WriteString(data)
+}
+
+func TestHighlighter(t *testing.T) {
+	out := &recordedWriter{}
+	h := newHighlighter(out, []prompt.Suggest{{Text: "MEAN()"}, {Text: "COUNT(time)"}})
+	h.theme = themes["dark"]
+
+	h.SetColor(prompt.Blue, prompt.DefaultColor, false)
+	h.WriteStr("> ")
+	h.SetColor(inputMarker, prompt.DefaultColor, false)
+	h.WriteStr("select count(x) from cpu")
+	h.SetColor(prompt.DefaultColor, prompt.DefaultColor, false)
+	h.WriteStr(" suggestion")
+	require.Equal(t, fmt.Sprintf("<%d>> <%d>select <%d>count(x) <%d>from cpu suggestion", prompt.Blue, prompt.Blue, prompt.Fuchsia, prompt.Blue),
+		out.buf.String())
+
+	out.buf.Reset()
+	h.theme = themes[ThemeOff]
+	h.SetColor(inputMarker, prompt.DefaultColor, false)
+	h.WriteStr("select 1")
+	require.Equal(t, "select 1", out.buf.String())
+}
+
+func TestHighlighterPrefix(t *testing.T) {
+	out := &recordedWriter{}
+	h := newHighlighter(out, nil)
+	h.theme = themes["dark"]
+	h.prefixStyle = PrefixProduction
+	h.SetColor(prefixMarker, prompt.DefaultColor, false)
+	h.WriteStr("prod> ")
+	require.Equal(t, fmt.Sprintf("<%d>prod> ", prompt.Red), out.buf.String())
+
+	// the prefix is not colored when the colors are off
+	out.buf.Reset()
+	h.theme = themes[ThemeOff]
+	h.SetColor(prefixMarker, prompt.DefaultColor, false)
+	h.WriteStr("prod> ")
+	require.Equal(t, "prod> ", out.buf.String())
+}
+
+func TestDefaultTheme(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	require.Equal(t, "dark", DefaultTheme())
+	t.Setenv("NO_COLOR", "1")
+	require.Equal(t, ThemeOff, DefaultTheme())
+	require.NoError(t, ValidateTheme("Light"))
+	require.ErrorContains(t, ValidateTheme("solarized"), `unknown theme "solarized"`)
+}
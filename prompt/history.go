@@ -0,0 +1,121 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// History keeps the entered statements across sessions in a file, one statement per line.
+// Duplicated statements are only kept at their latest position and the oldest statements
+// are dropped once the size is exceeded.
+type History struct {
+	path    string
+	size    int
+	entries []string
+}
+
+// DefaultHistoryPath returns ~/.ts-cli/history
+func DefaultHistoryPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".ts-cli", "history"), nil
+}
+
+// NewHistory creates the history stored in path, a size less than or equal to 0 disables the history
+func NewHistory(path string, size int) *History {
+	return &History{path: path, size: size}
+}
+
+// Load reads the entries from the history file, a missing file is an empty history
+func (h *History) Load() error {
+	if h.size <= 0 {
+		return nil
+	}
+	file, err := os.Open(h.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	h.entries = h.entries[:0]
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		h.add(scanner.Text())
+	}
+	return scanner.Err()
+}
+
+// Add records the statement and saves the history file
+func (h *History) Add(line string) error {
+	if h.size <= 0 || !h.add(line) {
+		return nil
+	}
+	return h.save()
+}
+
+func (h *History) add(line string) bool {
+	// the lines of a multi-line statement are joined to keep one statement per line
+	line = strings.Join(strings.FieldsFunc(strings.TrimSpace(line), func(r rune) bool { return r == '\n' || r == '\r' }), " ")
+	if line == "" {
+		return false
+	}
+	h.entries = slices.DeleteFunc(h.entries, func(entry string) bool { return entry == line })
+	h.entries = append(h.entries, line)
+	if len(h.entries) > h.size {
+		h.entries = h.entries[len(h.entries)-h.size:]
+	}
+	return true
+}
+
+// save rewrites the whole history file, which is only readable by the current user
+func (h *History) save() error {
+	if err := os.MkdirAll(filepath.Dir(h.path), 0700); err != nil {
+		return err
+	}
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strings.Join(h.entries, "\n")+"\n"), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, h.path)
+}
+
+// Entries returns the statements from the oldest to the latest
+func (h *History) Entries() []string {
+	return h.entries
+}
+
+// Search looks for the latest entry containing query before the index from, it returns
+// the index of the entry or -1 if there is no such entry
+func (h *History) Search(query string, from int) int {
+	from = min(from, len(h.entries))
+	for i := from - 1; i >= 0; i-- {
+		if strings.Contains(h.entries[i], query) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory_AddAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".ts-cli", "history")
+	history := NewHistory(path, 3)
+	require.NoError(t, history.Load())
+	require.Empty(t, history.Entries())
+
+	for _, line := range []string{"show databases", "use db0", "  ", "show databases", "select * from cpu", "show users"} {
+		require.NoError(t, history.Add(line))
+	}
+	require.Equal(t, []string{"show databases", "select * from cpu", "show users"}, history.Entries())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	loaded := NewHistory(path, 2)
+	require.NoError(t, loaded.Load())
+	require.Equal(t, []string{"select * from cpu", "show users"}, loaded.Entries())
+}
+
+func TestHistory_Disabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	history := NewHistory(path, 0)
+	require.NoError(t, history.Add("show databases"))
+	require.Empty(t, history.Entries())
+	_, err := os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestHistory_Search(t *testing.T) {
+	history := NewHistory(filepath.Join(t.TempDir(), "history"), 10)
+	for _, line := range []string{"select * from cpu", "show databases", "select * from mem"} {
+		require.NoError(t, history.Add(line))
+	}
+	require.Equal(t, 2, history.Search("select", 3))
+	require.Equal(t, 0, history.Search("select", 2))
+	require.Equal(t, -1, history.Search("select", 0))
+	require.Equal(t, -1, history.Search("drop", 3))
+}
+
+func TestHistory_MultiLineStatement(t *testing.T) {
+	history := NewHistory(filepath.Join(t.TempDir(), "history"), 10)
+	require.NoError(t, history.Add("SELECT *\nFROM cpu\r\nWHERE host = 'a';"))
+	require.Equal(t, []string{"SELECT * FROM cpu WHERE host = 'a';"}, history.Entries())
+}
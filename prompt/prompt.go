@@ -19,6 +19,8 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"slices"
+	"strings"
 
 	"github.com/openGemini/go-prompt"
 
@@ -28,18 +30,46 @@ import (
 type Prompt struct {
 	completer *Completer
 	instance  *prompt.Prompt
+	// highlighter colors the input by the syntax of the statement
+	highlighter *highlighter
+	history     *History
+	search      reverseSearch
+	// continued is set while the statement entered is incomplete
+	continued bool
+	// interrupted is called when Ctrl-C clears the input
+	interrupted func()
+	// prefix returns the prefix of the prompt and its style, nil uses "> "
+	prefix func() (string, PrefixStyle)
 }
 
-func NewPrompt(executor prompt.Executor) *Prompt {
-	var completer = NewCompleter()
-	var p = &Prompt{completer: completer}
+// reverseSearch is the state of the Ctrl-R history search, it lasts as long as
+// the buffer holds the matched entry. The typed characters extend the query and
+// Backspace shrinks it.
+type reverseSearch struct {
+	active bool
+	failed bool
+	query  string
+	index  int
+	match  string
+}
+
+func NewPrompt(executor prompt.Executor, history *History, metadata Metadata, queries *SavedQueries) *Prompt {
+	var completer = NewCompleter(metadata, queries)
+	var p = &Prompt{completer: completer, history: history}
+	p.highlighter = newHighlighter(prompt.NewStdoutWriter(), completer.aggregateFuncs)
+	p.highlighter.theme = themes[DefaultTheme()]
 	var instance = prompt.New(
 		executor,
 		completer.completer,
 		prompt.OptionTitle("openGemini: interactive openGemini client"),
 		prompt.OptionPrefix("> "),
-		prompt.OptionPrefixTextColor(prompt.DefaultColor),
-		prompt.OptionCompletionWordSeparator(string([]byte{' ', os.PathSeparator})),
+		prompt.OptionLivePrefix(p.livePrefix),
+		prompt.OptionHistory(slices.Clone(history.Entries())),
+		prompt.OptionSetExitCheckerOnInput(p.checkInput),
+		prompt.OptionPrefixTextColor(prefixMarker),
+		prompt.OptionWriter(p.highlighter),
+		prompt.OptionInputTextColor(inputMarker),
+		prompt.OptionCompletionWordSeparator(wordSeparator),
 		prompt.OptionAddASCIICodeBind(
 			prompt.ASCIICodeBind{
 				ASCIICode: []byte{0x1b, 0x62},
@@ -61,7 +91,23 @@ func NewPrompt(executor prompt.Executor) *Prompt {
 			},
 			prompt.KeyBind{
 				Key: prompt.ControlC,
-				Fn:  p.Destruction,
+				Fn:  p.interrupt,
+			},
+			prompt.KeyBind{
+				Key: prompt.ControlR,
+				Fn:  p.reverseSearch,
+			},
+			prompt.KeyBind{
+				Key: prompt.NotDefined,
+				Fn:  p.searchInput,
+			},
+			prompt.KeyBind{
+				Key: prompt.Backspace,
+				Fn:  p.searchBackspace,
+			},
+			prompt.KeyBind{
+				Key: prompt.ControlH,
+				Fn:  p.searchBackspace,
 			},
 		),
 	)
@@ -69,9 +115,13 @@ func NewPrompt(executor prompt.Executor) *Prompt {
 	return p
 }
 
-func (p *Prompt) Run() {
-	fmt.Printf("openGemini CLI %s (rev-%s)\n", common.Version, common.GitCommit)
-	fmt.Println("Please use `quit`, `\\q`, `exit`, `Ctrl-C` or `Ctrl-D` to exit this program.")
+// Run reads the statements until the shell quits, serverVersion is shown next to the version of the CLI
+func (p *Prompt) Run(serverVersion string) {
+	if serverVersion == "" {
+		serverVersion = "unknown"
+	}
+	fmt.Printf("openGemini CLI %s (rev-%s), server version %s\n", common.Version, common.GitCommit, serverVersion)
+	fmt.Println("Please use `quit`, `\\q`, `exit` or `Ctrl-D` to exit this program, `Ctrl-C` cancels the running query.")
 	defer p.Destruction(nil)
 	p.instance.Run()
 }
@@ -88,3 +138,114 @@ func (p *Prompt) Destruction(_ *prompt.Buffer) {
 func (p *Prompt) SwitchCompleter(s bool) {
 	p.completer.switchCompleter(s)
 }
+
+// reverseSearch replaces the buffer with the latest history entry containing the text
+// of the buffer, pressing Ctrl-R again moves on to the older matched entries
+func (p *Prompt) reverseSearch(buf *prompt.Buffer) {
+	if !p.search.active {
+		p.search = reverseSearch{active: true, query: buf.Text(), index: len(p.history.Entries()), match: buf.Text()}
+	}
+	p.searchFrom(buf, p.search.index)
+}
+
+// searchInput is called after a character is inserted, during the search it is appended
+// to the query and the current entry is matched again before the older ones
+func (p *Prompt) searchInput(buf *prompt.Buffer) {
+	text := buf.Text()
+	if !p.search.active || len(text) <= len(p.search.match) || !strings.HasPrefix(text, p.search.match) {
+		return
+	}
+	p.search.query += text[len(p.search.match):]
+	p.searchFrom(buf, p.search.index+1)
+}
+
+// searchBackspace is called after a character is deleted, during the search the query
+// loses its last character and is searched again from the latest entry
+func (p *Prompt) searchBackspace(buf *prompt.Buffer) {
+	if !p.search.active {
+		return
+	}
+	query := []rune(p.search.query)
+	if len(query) != 0 {
+		p.search.query = string(query[:len(query)-1])
+	}
+	p.search.failed = false
+	p.search.index = len(p.history.Entries())
+	if p.search.query == "" {
+		p.search.match = ""
+		setText(buf, "")
+		return
+	}
+	p.searchFrom(buf, p.search.index)
+}
+
+// searchFrom shows the latest entry before the index from containing the query, the
+// last matched entry stays if there is none
+func (p *Prompt) searchFrom(buf *prompt.Buffer, from int) {
+	if index := p.history.Search(p.search.query, from); index < 0 {
+		p.search.failed = true
+	} else {
+		p.search.failed = false
+		p.search.index = index
+		p.search.match = p.history.Entries()[index]
+	}
+	setText(buf, p.search.match)
+}
+
+func setText(buf *prompt.Buffer, text string) {
+	buf.DeleteBeforeCursor(len([]rune(buf.Document().TextBeforeCursor())))
+	buf.Delete(len([]rune(buf.Text())))
+	buf.InsertText(text, false, true)
+}
+
+func (p *Prompt) livePrefix() (string, bool) {
+	if p.search.failed {
+		return fmt.Sprintf("(failed reverse-i-search)`%s': ", p.search.query), true
+	}
+	if p.search.active {
+		return fmt.Sprintf("(reverse-i-search)`%s': ", p.search.query), true
+	}
+	if p.continued {
+		return "... ", true
+	}
+	if p.prefix != nil {
+		prefix, style := p.prefix()
+		p.highlighter.prefixStyle = style
+		return prefix, true
+	}
+	return "", false
+}
+
+// SetPrefix sets the function returning the prefix of the prompt, it is called whenever the
+// prompt is rendered so the prefix follows the changes of the connection and the database
+func (p *Prompt) SetPrefix(fn func() (string, PrefixStyle)) {
+	p.prefix = fn
+}
+
+// SetContinued switches to the continuation prompt until the statement is complete
+func (p *Prompt) SetContinued(continued bool) {
+	p.continued = continued
+}
+
+// SetInterruptHandler sets the function called when Ctrl-C clears the input at the prompt
+func (p *Prompt) SetInterruptHandler(fn func()) {
+	p.interrupted = fn
+}
+
+// interrupt is bound to Ctrl-C, the input has been cleared by go-prompt already and the
+// incomplete statement is dropped as well, like psql does
+func (p *Prompt) interrupt(_ *prompt.Buffer) {
+	p.continued = false
+	if p.interrupted != nil {
+		p.interrupted()
+	}
+}
+
+// checkInput is called after every key stroke, it stops the reverse search once the
+// matched entry is edited or executed and never exits the prompt
+func (p *Prompt) checkInput(input string, breakline bool) bool {
+	if p.search.active && (breakline || input != p.search.match) {
+		p.search = reverseSearch{}
+	}
+	return false
+}
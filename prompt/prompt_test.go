@@ -0,0 +1,82 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/openGemini/go-prompt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrompt_ReverseSearch(t *testing.T) {
+	history := NewHistory(filepath.Join(t.TempDir(), "history"), 10)
+	for _, line := range []string{"select * from cpu", "show databases", "select * from mem", "show measurements"} {
+		require.NoError(t, history.Add(line))
+	}
+	p := &Prompt{history: history}
+	buf := prompt.NewBuffer()
+	// the key bindings run after go-prompt edited the buffer, then the input is checked
+	ctrlR := func() {
+		p.reverseSearch(buf)
+		p.checkInput(buf.Text(), false)
+	}
+	typeText := func(text string) {
+		for _, r := range text {
+			buf.InsertText(string(r), false, true)
+			p.searchInput(buf)
+			p.checkInput(buf.Text(), false)
+		}
+	}
+	backspace := func() {
+		buf.DeleteBeforeCursor(1)
+		p.searchBackspace(buf)
+		p.checkInput(buf.Text(), false)
+	}
+	requireSearch := func(prefix, text string) {
+		t.Helper()
+		livePrefix, ok := p.livePrefix()
+		require.True(t, ok)
+		require.Equal(t, prefix, livePrefix)
+		require.Equal(t, text, buf.Text())
+	}
+
+	ctrlR()
+	requireSearch("(reverse-i-search)`': ", "show measurements")
+	typeText("s")
+	requireSearch("(reverse-i-search)`s': ", "show measurements")
+	typeText("el")
+	requireSearch("(reverse-i-search)`sel': ", "select * from mem")
+	ctrlR()
+	requireSearch("(reverse-i-search)`sel': ", "select * from cpu")
+	ctrlR()
+	requireSearch("(failed reverse-i-search)`sel': ", "select * from cpu")
+	backspace()
+	requireSearch("(reverse-i-search)`se': ", "select * from mem")
+	typeText("x")
+	requireSearch("(failed reverse-i-search)`sex': ", "select * from mem")
+	backspace()
+	backspace()
+	backspace()
+	requireSearch("(reverse-i-search)`': ", "")
+	typeText("data")
+	requireSearch("(reverse-i-search)`data': ", "show databases")
+
+	// executing the matched entry ends the search
+	p.checkInput(buf.Text(), true)
+	_, ok := p.livePrefix()
+	require.False(t, ok)
+}
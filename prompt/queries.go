@@ -0,0 +1,92 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+)
+
+// SavedQueries keeps the statements saved under a name by `\save` in a toml file:
+//
+//	[queries]
+//	slow = "SHOW QUERIES"
+//	load = "SELECT mean(load) FROM cpu WHERE host = :'host' AND time > now() - 1h"
+type SavedQueries struct {
+	path    string
+	Queries map[string]string `toml:"queries"`
+}
+
+// DefaultSavedQueriesPath returns ~/.ts-cli/queries.toml
+func DefaultSavedQueriesPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".ts-cli", "queries.toml"), nil
+}
+
+func NewSavedQueries(path string) *SavedQueries {
+	return &SavedQueries{path: path, Queries: make(map[string]string)}
+}
+
+// Load reads the saved queries, a missing file has no saved queries
+func (q *SavedQueries) Load() error {
+	_, err := toml.DecodeFile(q.path, q)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read saved queries %s: %w", q.path, err)
+	}
+	if q.Queries == nil {
+		q.Queries = make(map[string]string)
+	}
+	return nil
+}
+
+// Save stores the statement under the name, replacing the one saved before, and saves the file
+func (q *SavedQueries) Save(name, statement string) error {
+	q.Queries[name] = statement
+	if err := os.MkdirAll(filepath.Dir(q.path), 0700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(q.path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err = toml.NewEncoder(file).Encode(q); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Get returns the statement saved under the name
+func (q *SavedQueries) Get(name string) (string, bool) {
+	statement, ok := q.Queries[name]
+	return statement, ok
+}
+
+// Names returns the sorted names of the saved queries
+func (q *SavedQueries) Names() []string {
+	if q == nil {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(q.Queries))
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 openGemini Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedQueries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ts-cli", "queries.toml")
+	queries := NewSavedQueries(path)
+	require.NoError(t, queries.Load())
+	require.Empty(t, queries.Names())
+
+	require.NoError(t, queries.Save("slow", "SHOW QUERIES"))
+	require.NoError(t, queries.Save("load", `SELECT load FROM cpu WHERE host = :'host'`))
+	require.NoError(t, queries.Save("slow", "SHOW QUERIES LIMIT 1"))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	loaded := NewSavedQueries(path)
+	require.NoError(t, loaded.Load())
+	require.Equal(t, []string{"load", "slow"}, loaded.Names())
+	statement, ok := loaded.Get("load")
+	require.True(t, ok)
+	require.Equal(t, `SELECT load FROM cpu WHERE host = :'host'`, statement)
+	statement, _ = loaded.Get("slow")
+	require.Equal(t, "SHOW QUERIES LIMIT 1", statement)
+	_, ok = loaded.Get("missing")
+	require.False(t, ok)
+}